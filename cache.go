@@ -0,0 +1,233 @@
+package gogtrends
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errCacheDir = "failed to create cache dir"
+
+	// fileCacheExt is an extension of FileCache entries
+	fileCacheExt = ".cache"
+)
+
+// Cache stores raw API responses, implementations should be safe for concurrent use.
+type Cache interface {
+	// Get returns not expired value by key.
+	Get(key string) ([]byte, bool)
+	// Set stores value by key for ttl duration.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// cacheKey is an API url with sorted query params. Widget tokens are changed for every Explore call,
+// but response is defined by widget request, so token is excluded.
+func cacheKey(req *Request) string {
+	q := make(url.Values, len(req.Params))
+	for k, v := range req.Params {
+		q[k] = v
+	}
+
+	if req.Widget != nil {
+		q.Del(paramToken)
+	}
+
+	return req.URL.Host + req.URL.Path + "?" + q.Encode()
+}
+
+// cached is a middleware which returns responses from client cache and stores successfully decoded ones.
+func (c *Client) cached(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		ttl := c.cacheTTL(req.Operation)
+		if ttl <= 0 || req.noCache {
+			return next(ctx, req)
+		}
+
+		key := cacheKey(req)
+		if b, ok := c.cache.Get(key); ok {
+			c.log().Debug("cache hit", "endpoint", req.Endpoint, "operation", req.Operation)
+			return &Response{StatusCode: http.StatusOK, Header: make(http.Header), Payload: b}, nil
+		}
+
+		resp, err := next(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		// payload can be html page or broken json, it's stored by client after it's decoded
+		payload := resp.Payload
+		resp.commit = func() {
+			c.cache.Set(key, payload, ttl)
+		}
+
+		return resp, nil
+	}
+}
+
+func (c *Client) cacheTTL(op Operation) time.Duration {
+	if c.cache == nil {
+		return 0
+	}
+
+	if ttl, ok := c.cacheTTLs[op]; ok {
+		return ttl
+	}
+
+	// widget tokens of explore response expire, it's cached only with explicit ttl
+	if op == OpExplore {
+		return 0
+	}
+
+	return c.cacheDefTTL
+}
+
+type memoryCacheItem struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// MemoryCache is an in-memory LRU Cache with limited number of entries.
+type MemoryCache struct {
+	mu    sync.Mutex
+	size  int
+	items map[string]*list.Element
+	lru   *list.List
+}
+
+// NewMemoryCache creates LRU cache for up to size entries, least recently used entries are removed first.
+func NewMemoryCache(size int) *MemoryCache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &MemoryCache{
+		size:  size,
+		items: make(map[string]*list.Element, size),
+		lru:   list.New(),
+	}
+}
+
+// Get implements Cache.
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	item := el.Value.(*memoryCacheItem)
+	if !item.expires.After(time.Now()) {
+		m.lru.Remove(el)
+		delete(m.items, key)
+		return nil, false
+	}
+
+	m.lru.MoveToFront(el)
+
+	return item.value, true
+}
+
+// Set implements Cache.
+func (m *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item := &memoryCacheItem{key: key, value: value, expires: time.Now().Add(ttl)}
+	if el, ok := m.items[key]; ok {
+		el.Value = item
+		m.lru.MoveToFront(el)
+		return
+	}
+
+	m.items[key] = m.lru.PushFront(item)
+	for m.lru.Len() > m.size {
+		el := m.lru.Back()
+		m.lru.Remove(el)
+		delete(m.items, el.Value.(*memoryCacheItem).key)
+	}
+}
+
+// Len returns number of cached entries, including expired but not removed yet.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.lru.Len()
+}
+
+// FileCache is a Cache which stores entries as files in directory, so it survives process restarts.
+type FileCache struct {
+	dir string
+}
+
+// NewFileCache creates file cache in dir, directory is created if it doesn't exist.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, errCacheDir)
+	}
+
+	return &FileCache{dir: dir}, nil
+}
+
+func (f *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(sum[:])+fileCacheExt)
+}
+
+// Get implements Cache.
+func (f *FileCache) Get(key string) ([]byte, bool) {
+	b, err := ioutil.ReadFile(f.path(key))
+	if err != nil || len(b) < 8 {
+		return nil, false
+	}
+
+	// entry is an expiration unix nano time followed by value
+	expires := time.Unix(0, int64(binary.BigEndian.Uint64(b[:8])))
+	if !expires.After(time.Now()) {
+		os.Remove(f.path(key))
+		return nil, false
+	}
+
+	return b[8:], true
+}
+
+// Set implements Cache, write errors are ignored, entry just stays missing.
+func (f *FileCache) Set(key string, value []byte, ttl time.Duration) {
+	b := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(b[:8], uint64(time.Now().Add(ttl).UnixNano()))
+	copy(b[8:], value)
+
+	tmp, err := ioutil.TempFile(f.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+
+	if err := os.Rename(tmp.Name(), f.path(key)); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
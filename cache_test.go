@@ -0,0 +1,154 @@
+package gogtrends
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/groovili/gogtrends/gogtrendstest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryCache(t *testing.T) {
+	m := NewMemoryCache(2)
+
+	m.Set("a", []byte("1"), time.Minute)
+	m.Set("b", []byte("2"), time.Minute)
+
+	// "a" becomes recently used, so "b" is evicted
+	v, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), v)
+
+	m.Set("c", []byte("3"), time.Minute)
+	assert.Equal(t, 2, m.Len())
+	_, ok = m.Get("b")
+	assert.False(t, ok)
+
+	m.Set("c", []byte("4"), -time.Second)
+	_, ok = m.Get("c")
+	assert.False(t, ok)
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestFileCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gogtrends")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	f, err := NewFileCache(dir)
+	assert.NoError(t, err)
+
+	f.Set("key", []byte("value"), time.Minute)
+	v, ok := f.Get("key")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("value"), v)
+
+	// survives recreation
+	f, err = NewFileCache(dir)
+	assert.NoError(t, err)
+	_, ok = f.Get("key")
+	assert.True(t, ok)
+
+	f.Set("key", []byte("value"), -time.Second)
+	_, ok = f.Get("key")
+	assert.False(t, ok)
+
+	_, ok = f.Get("unknown")
+	assert.False(t, ok)
+}
+
+func TestCacheKey(t *testing.T) {
+	u, _ := url.Parse(gAPI + gSIntOverTime)
+
+	p1 := url.Values{paramHl: {langEN}, paramToken: {"first"}, paramReq: {"{}"}}
+	p2 := url.Values{paramReq: {"{}"}, paramToken: {"second"}, paramHl: {langEN}}
+
+	w := &ExploreWidget{}
+	assert.Equal(t,
+		cacheKey(&Request{URL: u, Params: p1, Widget: w}),
+		cacheKey(&Request{URL: u, Params: p2, Widget: w}),
+	)
+	assert.NotEqual(t, cacheKey(&Request{URL: u, Params: p1}), cacheKey(&Request{URL: u, Params: p2}))
+	// params of request are untouched
+	assert.Equal(t, "first", p1.Get(paramToken))
+}
+
+func TestClientCache(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path == gRealtime {
+			_, _ = w.Write([]byte(`)]}'{"storySummaries":{"trendingStories":[{"title":"Go"}]}}`))
+			return
+		}
+		_, _ = w.Write([]byte(dailyPayload))
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL), WithCache(NewMemoryCache(10), time.Minute), WithCacheTTL(OpRealtime, 0))
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		resp, err := c.Daily(ctx, langEN, locUS)
+		assert.NoError(t, err)
+		assert.Len(t, resp, 1)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// other params aren't cached together
+	_, err := c.Daily(ctx, langEN, "GB")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// disabled for operation
+	for i := 0; i < 2; i++ {
+		_, err := c.Realtime(ctx, langEN, locUS, catAll)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+}
+
+func TestClientCacheDecoded(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL), WithCache(NewMemoryCache(10), time.Minute))
+	ctx := context.Background()
+
+	// not decoded payloads aren't cached
+	srv.Script(gogtrendstest.PathDaily, gogtrendstest.HTMLPage(), gogtrendstest.Malformed())
+	_, err := c.Daily(ctx, langEN, locUS)
+	assert.True(t, errors.Is(err, ErrUnexpectedPayload))
+	_, err = c.Daily(ctx, langEN, locUS)
+	assert.Error(t, err)
+
+	for i := 0; i < 2; i++ {
+		daily, err := c.Daily(ctx, langEN, locUS)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, daily)
+	}
+	assert.Equal(t, 3, srv.Requests(gogtrendstest.PathDaily))
+
+	// explore is cached only with explicit ttl
+	req := &ExploreRequest{ComparisonItems: []*ComparisonItem{{Keyword: "Go", Time: "today 12-m"}}}
+	for i := 0; i < 2; i++ {
+		_, err := c.Explore(ctx, req, langEN)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, srv.Requests(gogtrendstest.PathExplore))
+
+	c = New(WithBaseURL(srv.URL), WithCache(NewMemoryCache(10), time.Minute), WithCacheTTL(OpExplore, time.Minute))
+	for i := 0; i < 2; i++ {
+		_, err := c.Explore(ctx, req, langEN)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 3, srv.Requests(gogtrendstest.PathExplore))
+}
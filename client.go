@@ -1,62 +1,112 @@
 package gogtrends
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
-
-	"log"
-
 	"github.com/pkg/errors"
 )
 
 const (
 	headerKeyAccept    = "Accept"
-	headerKeyCookie    = "Cookie"
-	headerKeySetCookie = "Set-Cookie"
+	headerKeyUserAgent = "User-Agent"
 	contentTypeJSON    = "application/json"
+
+	// decodeBufSize is a size of read buffer used for decoding, enough to look ahead for anti-XSSI prefix
+	decodeBufSize = 4096
 )
 
-type gClient struct {
+// xssiPrefix is an anti-XSSI prefix of Google Trends API responses, optionally followed by comma.
+var xssiPrefix = []byte(")]}'")
+
+// Client is a Google Trends API client. Every client has own http client, cookies and caches,
+// so few clients can be used independently. Use New to create it.
+type Client struct {
 	c         *http.Client
+	baseURL   string
+	headers   http.Header
 	defParams url.Values
+	retry     RetryPolicy
+
+	limiter  *rateLimiter
+	limiters map[string]*rateLimiter
 
 	tcm        *sync.RWMutex
 	trendsCats map[string]string
 
-	cm          *sync.RWMutex
-	exploreCats *ExploreCatTree
+	categories *pickerCache
+	locations  *pickerCache
+	validate   bool
+
+	jar       http.CookieJar
+	warmUpURL string
+
+	logger  atomic.Value
+	redact  bool
+	metrics Metrics
 
-	lm          *sync.RWMutex
-	exploreLocs *ExploreLocTree
+	middlewares []Middleware
+	handler     Handler
 
-	cookie string
-	debug  bool
+	cache       Cache
+	cacheDefTTL time.Duration
+	cacheTTLs   map[Operation]time.Duration
+
+	flights *flightGroup
 }
 
-func newGClient() *gClient {
+// New creates Client with default settings and applies provided options to it.
+func New(opts ...Option) *Client {
 	// default request params
 	p := make(url.Values)
 	for k, v := range defaultParams {
 		p.Add(k, v)
 	}
 
-	return &gClient{
+	c := &Client{
 		c:          http.DefaultClient,
+		baseURL:    gAPI,
+		headers:    make(http.Header),
 		defParams:  p,
+		retry:      DefaultRetryPolicy(),
+		limiters:   make(map[string]*rateLimiter),
 		tcm:        new(sync.RWMutex),
 		trendsCats: trendsCategories,
-		cm:         new(sync.RWMutex),
-		lm:         new(sync.RWMutex),
+		categories: newPickerCache(defaultPickerTTL),
+		locations:  newPickerCache(defaultPickerTTL),
+		validate:   true,
+		jar:        newSessionJar(),
+		warmUpURL:  gWarmUp,
+		redact:     true,
+		metrics:    nopMetrics{},
+		cacheTTLs:  make(map[Operation]time.Duration),
+		flights:    newFlightGroup(),
+	}
+	c.setLogger(nopLogger{})
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	// first added middleware is the outermost, cache and deduplication are right before transport
+	c.handler = c.cached(c.deduplicated(c.transport))
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		c.handler = c.middlewares[i](c.handler)
+	}
+
+	return c
 }
 
-func (c *gClient) defaultParams() url.Values {
+func (c *Client) defaultParams() url.Values {
 	out := make(map[string][]string, len(c.defParams))
 	for i, v := range c.defParams {
 		out[i] = make([]string, len(v))
@@ -66,90 +116,280 @@ func (c *gClient) defaultParams() url.Values {
 	return out
 }
 
-func (c *gClient) getCategories() *ExploreCatTree {
-	c.cm.RLock()
-	defer c.cm.RUnlock()
-	return c.exploreCats
-}
+// url builds API url for provided path relative to client base url.
+func (c *Client) url(path string) (*url.URL, error) {
+	u, err := url.Parse(c.baseURL + path)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateRequest)
+	}
 
-func (c *gClient) setCategories(cats *ExploreCatTree) {
-	c.cm.Lock()
-	defer c.cm.Unlock()
-	c.exploreCats = cats
+	return u, nil
 }
 
-func (c *gClient) getLocations() *ExploreLocTree {
-	c.lm.RLock()
-	defer c.lm.RUnlock()
-	return c.exploreLocs
+// wait blocks until request to endpoint is allowed by client rate limits.
+func (c *Client) wait(ctx context.Context, endpoint string) error {
+	if err := c.limiter.wait(ctx); err != nil {
+		return err
+	}
+
+	return c.limiters[endpoint].wait(ctx)
 }
 
-func (c *gClient) setLocations(locs *ExploreLocTree) {
-	c.lm.Lock()
-	defer c.lm.Unlock()
-	c.exploreLocs = locs
+// roundTrip is an outcome of request with all its attempts.
+type roundTrip struct {
+	status   int
+	attempts int
+	cookies  []*http.Cookie
 }
 
-func (c *gClient) do(ctx context.Context, u *url.URL) ([]byte, error) {
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+// do passes request through middleware chain and decodes response payload into dest.
+// Response is stored in cache only after it's successfully decoded.
+func (c *Client) do(ctx context.Context, req *Request, dest interface{}) error {
+	if req.Params == nil {
+		req.Params = make(url.Values)
+	}
+
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+
+	// middlewares and cache work with raw payload, otherwise body is decoded while it's read
+	req.stream = len(c.middlewares) == 0 && (req.noCache || c.cacheTTL(req.Operation) <= 0)
+
+	resp, err := c.handler(ctx, req)
 	if err != nil {
-		return nil, errors.Wrap(err, errCreateRequest)
+		return err
 	}
 
-	r.Header.Add(headerKeyAccept, contentTypeJSON)
+	if err := resp.Decode(dest); err != nil {
+		return err
+	}
 
-	if len(client.cookie) != 0 {
-		r.Header.Add(headerKeyCookie, client.cookie)
+	if resp.commit != nil {
+		resp.commit()
 	}
 
-	if client.debug {
-		log.Println("[Debug] Request with params: ", r.URL)
+	return nil
+}
+
+// transport is the last handler of middleware chain, it performs http request.
+func (c *Client) transport(ctx context.Context, req *Request) (*Response, error) {
+	u := *req.URL
+	u.RawQuery = req.Params.Encode()
+
+	start := time.Now()
+	rt := new(roundTrip)
+	done := func(size int, err error) {
+		latency := time.Since(start)
+		c.logRequest(req.Endpoint, &u, rt, latency, size, err)
+		c.metrics.RequestDone(req.Operation, rt.status, err != nil, latency, size)
 	}
 
-	resp, err := c.c.Do(r)
+	resp, err := c.send(ctx, req, &u, rt)
 	if err != nil {
-		return nil, errors.Wrap(err, errDoRequest)
+		done(0, err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if client.debug {
-		log.Println("[Debug] Response: ", resp)
+	// size of streamed response is known when it's read
+	if resp.body != nil {
+		resp.body = &countingBody{ReadCloser: resp.body, done: done}
+		return resp, nil
 	}
 
-	if resp.StatusCode == http.StatusTooManyRequests {
-		cookie := strings.Split(resp.Header.Get(headerKeySetCookie), ";")
-		if len(cookie) > 0 {
-			client.cookie = cookie[0]
-			r.Header.Set(headerKeyCookie, cookie[0])
+	done(len(resp.Payload), nil)
 
-			resp, err = c.c.Do(r)
-			if err != nil {
-				return nil, err
+	return resp, nil
+}
+
+// countingBody counts read bytes of streamed response and reports request when body is closed.
+type countingBody struct {
+	io.ReadCloser
+	size int
+	done func(size int, err error)
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.size += n
+
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.done != nil {
+		b.done(b.size, nil)
+		b.done = nil
+	}
+
+	return err
+}
+
+// send performs request with retries, rt is filled with request details.
+func (c *Client) send(ctx context.Context, req *Request, u *url.URL, rt *roundTrip) (*Response, error) {
+	endpoint := req.Endpoint
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, errCreateRequest)
+	}
+
+	r.Header.Add(headerKeyAccept, contentTypeJSON)
+	for k, v := range c.headers {
+		r.Header[k] = v
+	}
+
+	for k, v := range req.Header {
+		r.Header[k] = v
+	}
+
+	for attempt := 1; ; attempt++ {
+		if err := c.wait(ctx, endpoint); err != nil {
+			return nil, errors.Wrap(err, errDoRequest)
+		}
+
+		// cookies are taken on every attempt, they can be refreshed by previous response
+		hr := r.Clone(ctx)
+		rt.cookies = c.jar.Cookies(u)
+		for _, v := range rt.cookies {
+			hr.AddCookie(v)
+		}
+
+		rt.attempts = attempt
+		resp, err := c.c.Do(hr)
+		if err != nil {
+			rt.status = 0
+			if !c.retry.retryable(ctx, attempt, nil, err) {
+				return nil, errors.Wrap(err, errDoRequest)
+			}
+
+			c.log().Debug("retry request", "endpoint", endpoint, "attempt", attempt, "error", err)
+			c.metrics.Retried(req.Operation, 0)
+			if err := sleep(ctx, c.retry.backoff(attempt)); err != nil {
+				return nil, errors.Wrap(err, errDoRequest)
+			}
+
+			continue
+		}
+
+		rt.status = resp.StatusCode
+		cookies := resp.Cookies()
+		c.jar.SetCookies(u, cookies)
+
+		if resp.StatusCode == http.StatusOK {
+			if req.stream {
+				return &Response{StatusCode: resp.StatusCode, Header: resp.Header, body: resp.Body}, nil
 			}
 			defer resp.Body.Close()
+
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return nil, errors.Wrap(err, errDoRequest)
+			}
+
+			return &Response{StatusCode: resp.StatusCode, Header: resp.Header, Payload: b}, nil
+		}
+
+		// keep beginning of body for error and drain the rest to reuse connection
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, errBodyExcerptLen))
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+
+		reqErr := newHTTPError(endpoint, u, resp, body)
+		if !c.retry.retryable(ctx, attempt, resp, nil) {
+			return nil, reqErr
+		}
+
+		delay := c.retry.backoff(attempt)
+
+		// google asks to repeat request with new cookies
+		if resp.StatusCode == http.StatusTooManyRequests && len(cookies) > 0 {
+			c.metrics.CookiesRefreshed(req.Operation)
+			delay = 0
+		}
+
+		if reqErr.RetryAfter > 0 {
+			if c.retry.MaxBackoff > 0 && reqErr.RetryAfter > c.retry.MaxBackoff {
+				return nil, reqErr
+			}
+
+			if reqErr.RetryAfter > delay {
+				delay = reqErr.RetryAfter
+			}
+		}
+
+		c.log().Debug("retry request", "endpoint", endpoint, "attempt", attempt, "status", resp.StatusCode, "delay", delay)
+		c.metrics.Retried(req.Operation, resp.StatusCode)
+		if err := sleep(ctx, delay); err != nil {
+			return nil, errors.Wrap(err, errDoRequest)
 		}
 	}
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.Wrapf(ErrRequestFailed, errReqDataF, resp.StatusCode, resp.Status)
+// decode strips anti-XSSI prefix and decodes json from r into dest.
+func decode(r io.Reader, dest interface{}) error {
+	br := bufio.NewReaderSize(r, decodeBufSize)
+	if err := stripXSSI(br); err != nil {
+		return err
+	}
+
+	if err := jsoniter.NewDecoder(br).Decode(dest); err != nil {
+		return errors.Wrap(err, errParsing)
 	}
 
-	return ioutil.ReadAll(resp.Body)
+	return nil
 }
 
-func (c *gClient) unmarshal(str string, dest interface{}) error {
-	if err := jsoniter.UnmarshalFromString(str, dest); err != nil {
-		return errors.Wrap(err, errParsing)
+// stripXSSI discards leading whitespace and any known anti-XSSI prefix,
+// google api returns not valid json :(
+func stripXSSI(br *bufio.Reader) error {
+	skipSpace(br)
+
+	head, _ := br.Peek(len(xssiPrefix))
+	if len(head) > 0 && head[0] == '<' {
+		return ErrUnexpectedPayload
+	}
+
+	if !bytes.Equal(head, xssiPrefix) {
+		return nil
+	}
+	_, _ = br.Discard(len(xssiPrefix))
+
+	// prefix is followed by comma and line break on some endpoints
+	skipSpace(br)
+	if b, err := br.Peek(1); err == nil && b[0] == ',' {
+		_, _ = br.Discard(1)
 	}
 
 	return nil
 }
 
-func (c *gClient) trends(ctx context.Context, path, hl, loc string, args ...map[string]string) (string, error) {
-	u, _ := url.Parse(path)
+func skipSpace(br *bufio.Reader) {
+	for {
+		b, err := br.Peek(1)
+		if err != nil {
+			return
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			_, _ = br.Discard(1)
+		default:
+			return
+		}
+	}
+}
+
+func (c *Client) trends(ctx context.Context, op Operation, path, hl, loc string, dest interface{},
+	args ...map[string]string) error {
+	u, err := c.url(path)
+	if err != nil {
+		return err
+	}
 
 	// required params
-	p := client.defaultParams()
+	p := c.defaultParams()
 	if len(loc) > 0 {
 		p.Set(paramGeo, loc)
 	}
@@ -164,19 +404,12 @@ func (c *gClient) trends(ctx context.Context, path, hl, loc string, args ...map[
 		}
 	}
 
-	u.RawQuery = p.Encode()
-
-	data, err := client.do(ctx, u)
-	if err != nil {
-		return "", err
-	}
-
-	return string(data), nil
+	return c.do(ctx, &Request{Operation: op, Endpoint: path, URL: u, Params: p}, dest)
 }
 
-func (c *gClient) validateCategory(cat string) bool {
+func (c *Client) validateCategory(cat string) bool {
 	c.tcm.RLock()
-	_, ok := client.trendsCats[cat]
+	_, ok := c.trendsCats[cat]
 	c.tcm.RUnlock()
 
 	return ok
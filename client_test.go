@@ -0,0 +1,434 @@
+package gogtrends
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/groovili/gogtrends/gogtrendstest"
+	"github.com/pkg/errors"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const dailyPayload = `)]}',{"default":{"trendingSearchesDays":[{"formattedDate":"Friday, October 16, 2026",` +
+	`"trendingSearches":[{"title":{"query":"Golang"},"formattedTraffic":"200K+"}]}]}}`
+
+func TestClientOptions(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, gDaily, r.URL.Path)
+		assert.Equal(t, "gogtrends-test", r.UserAgent())
+		assert.Equal(t, "secret", r.Header.Get("X-Proxy-Auth"))
+		assert.Equal(t, "50", r.URL.Query().Get("rs"))
+		assert.Equal(t, locUS, r.URL.Query().Get(paramGeo))
+
+		_, _ = w.Write([]byte(dailyPayload))
+	}))
+	defer srv.Close()
+
+	h := make(http.Header)
+	h.Set("X-Proxy-Auth", "secret")
+
+	c := New(
+		WithHTTPClient(srv.Client()),
+		WithBaseURL(srv.URL+"/"),
+		WithUserAgent("gogtrends-test"),
+		WithHeaders(h),
+		WithDefaultParam("rs", "50"),
+	)
+
+	resp, err := c.Daily(context.Background(), langEN, locUS)
+	assert.NoError(t, err)
+	assert.Len(t, resp, 1)
+	assert.Equal(t, "Golang", resp[0].Title.Query)
+
+	// default client must stay untouched
+	assert.Equal(t, gAPI, client.baseURL)
+	assert.Equal(t, "20", client.defParams.Get("rs"))
+}
+
+func TestClientRetry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.Header().Set(headerKeyRetryAfter, "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(dailyPayload))
+		}
+	}))
+	defer srv.Close()
+
+	p := DefaultRetryPolicy()
+	p.MinBackoff = time.Millisecond
+
+	c := New(WithBaseURL(srv.URL), WithRetryPolicy(p))
+	resp, err := c.Daily(context.Background(), langEN, locUS)
+	assert.NoError(t, err)
+	assert.Len(t, resp, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// bad request is not retried
+	atomic.StoreInt32(&calls, 0)
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	_, err = c.Daily(context.Background(), langEN, locUS)
+	assert.True(t, errors.Is(err, ErrRequestFailed))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// too long Retry-After stops retries
+	atomic.StoreInt32(&calls, 0)
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set(headerKeyRetryAfter, "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err = c.Daily(context.Background(), langEN, locUS)
+	assert.True(t, errors.Is(err, ErrRequestFailed))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// hook decides for every error
+	atomic.StoreInt32(&calls, 0)
+	srv.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	p.ShouldRetry = func(resp *http.Response, err error) bool {
+		return resp != nil && resp.StatusCode == http.StatusTooManyRequests
+	}
+
+	c = New(WithBaseURL(srv.URL), WithRetryPolicy(p))
+	_, err = c.Daily(context.Background(), langEN, locUS)
+	assert.True(t, errors.Is(err, ErrRequestFailed))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, p.backoff(1))
+	assert.Equal(t, 2*time.Second, p.backoff(2))
+	assert.Equal(t, 4*time.Second, p.backoff(3))
+	assert.Equal(t, 5*time.Second, p.backoff(10))
+
+	// no limit
+	p.MaxBackoff = 0
+	assert.Equal(t, time.Second, p.backoff(1))
+	assert.Equal(t, 2*time.Second, p.backoff(2))
+	assert.Equal(t, 16*time.Second, p.backoff(5))
+	assert.True(t, p.backoff(100) > 0)
+
+	p.Jitter = 0.5
+	d := p.backoff(1)
+	assert.True(t, d >= time.Second && d <= 1500*time.Millisecond)
+
+	// jitter doesn't overflow not limited delay
+	p.Jitter = 0.2
+	for _, attempt := range []int{33, 40, 64, 100} {
+		assert.True(t, p.backoff(attempt) > 0, attempt)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(50, 2)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, l.wait(context.Background()))
+	}
+	// burst of 2 is free, next 3 requests wait 20ms each
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Error(t, l.wait(ctx))
+
+	var nilLimiter *rateLimiter
+	assert.NoError(t, nilLimiter.wait(context.Background()))
+}
+
+func TestClientEndpointRateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(dailyPayload))
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL), WithEndpointRateLimit(EndpointDaily, 1, 1))
+
+	_, err := c.Daily(context.Background(), langEN, locUS)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = c.Daily(ctx, langEN, locUS)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == gSExplore {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(strings.Repeat("b", 2*errBodyExcerptLen)))
+			return
+		}
+
+		w.Header().Set(headerKeyRetryAfter, "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+
+	_, err := c.Daily(context.Background(), langEN, locUS)
+	assert.True(t, errors.Is(err, ErrRequestFailed))
+	assert.True(t, IsRateLimited(err))
+	assert.False(t, IsServerError(err))
+
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, EndpointDaily, httpErr.Endpoint)
+	assert.Equal(t, 2*time.Minute, httpErr.RetryAfter)
+
+	_, err = c.Explore(context.Background(), &ExploreRequest{
+		ComparisonItems: []*ComparisonItem{{Keyword: "Golang", Time: "today 12-m"}},
+	}, langEN)
+	assert.True(t, errors.As(errors.Wrap(err, "wrapped"), &httpErr))
+	assert.Equal(t, http.StatusBadRequest, httpErr.StatusCode)
+	assert.Equal(t, EndpointExplore, httpErr.Endpoint)
+	assert.Len(t, httpErr.Body, errBodyExcerptLen)
+	assert.False(t, IsRateLimited(err))
+}
+
+func TestDecode(t *testing.T) {
+	cases := map[string]error{
+		`{"name":"ok"}`:                nil,
+		`)]}'{"name":"ok"}`:            nil,
+		`)]}',{"name":"ok"}`:           nil,
+		")]}'\n{\"name\":\"ok\"}":      nil,
+		")]}',\n{\"name\":\"ok\"}":     nil,
+		"\n)]}'\n,{\"name\":\"ok\"}":   nil,
+		`<!DOCTYPE html><html></html>`: ErrUnexpectedPayload,
+		`  <html></html>`:              ErrUnexpectedPayload,
+	}
+
+	for in, expErr := range cases {
+		out := new(ExploreCatTree)
+		err := decode(strings.NewReader(in), out)
+		if expErr != nil {
+			assert.True(t, errors.Is(err, expErr), in)
+			continue
+		}
+
+		assert.NoError(t, err, in)
+		assert.Equal(t, "ok", out.Name, in)
+	}
+
+	err := decode(strings.NewReader(`)]}',{"name":`), new(ExploreCatTree))
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrUnexpectedPayload))
+}
+
+func TestClientStreaming(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(dailyPayload))
+	}))
+	defer srv.Close()
+
+	m := new(sizeMetrics)
+	c := New(WithBaseURL(srv.URL), WithMetrics(m))
+	ctx := context.Background()
+
+	// body is decoded while it's read, request is reported after that
+	u, err := c.url(gDaily)
+	assert.NoError(t, err)
+	resp, err := c.handler(ctx, &Request{Operation: OpDaily, Endpoint: gDaily, URL: u, Params: make(url.Values), stream: true})
+	assert.NoError(t, err)
+	assert.Nil(t, resp.Payload)
+	assert.Empty(t, m.get())
+
+	assert.NoError(t, resp.Decode(new(dailyOut)))
+	assert.Equal(t, []int{len(dailyPayload)}, m.get())
+
+	daily, err := c.Daily(ctx, langEN, locUS)
+	assert.NoError(t, err)
+	assert.Len(t, daily, 1)
+
+	// middlewares get raw payload
+	var payload []byte
+	c = New(WithBaseURL(srv.URL), WithMiddleware(func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			resp, err := next(ctx, req)
+			if err == nil {
+				payload = resp.Payload
+			}
+			return resp, err
+		}
+	}))
+
+	daily, err = c.Daily(ctx, langEN, locUS)
+	assert.NoError(t, err)
+	assert.Len(t, daily, 1)
+	assert.Equal(t, dailyPayload, string(payload))
+}
+
+type sizeMetrics struct {
+	nopMetrics
+	mu    sync.Mutex
+	sizes []int
+}
+
+func (m *sizeMetrics) RequestDone(_ Operation, _ int, _ bool, _ time.Duration, size int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sizes = append(m.sizes, size)
+}
+
+func (m *sizeMetrics) get() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]int(nil), m.sizes...)
+}
+
+func TestClientUnexpectedPayload(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+	srv.Script(gogtrendstest.PathDaily, gogtrendstest.HTMLPage())
+
+	_, err := c.Daily(context.Background(), langEN, locUS)
+	assert.True(t, errors.Is(err, ErrUnexpectedPayload))
+
+	daily, err := c.Daily(context.Background(), langEN, locUS)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, daily)
+}
+
+func TestSanitizeQuery(t *testing.T) {
+	q := make(url.Values)
+	q.Set(paramToken, "secret-token")
+	q.Set(paramHl, langEN)
+
+	s := sanitizeQuery(q)
+	assert.NotContains(t, s, "secret-token")
+	assert.Contains(t, s, paramToken+"="+redacted)
+	assert.Contains(t, s, paramHl+"="+langEN)
+}
+
+type recordLogger struct {
+	nopLogger
+	mu   sync.Mutex
+	msgs []string
+	args [][]interface{}
+}
+
+func (l *recordLogger) Debug(msg string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.msgs = append(l.msgs, msg)
+	l.args = append(l.args, args)
+}
+
+func TestClientLogger(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "NID", Value: "secret-cookie"})
+		_, _ = w.Write([]byte(dailyPayload))
+	}))
+	defer srv.Close()
+
+	l := new(recordLogger)
+	c := New(WithBaseURL(srv.URL), WithLogger(l))
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Daily(context.Background(), langEN, locUS)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"request", "request"}, l.msgs)
+	line := fmt.Sprint(l.args[1]...)
+	assert.Contains(t, line, EndpointDaily)
+	assert.Contains(t, line, "NID="+redacted)
+	assert.NotContains(t, line, "secret-cookie")
+
+	l = new(recordLogger)
+	c = New(WithBaseURL(srv.URL), WithLogger(l), WithLogRedaction(false))
+	for i := 0; i < 2; i++ {
+		_, err := c.Daily(context.Background(), langEN, locUS)
+		assert.NoError(t, err)
+	}
+	assert.Contains(t, fmt.Sprint(l.args[1]...), "secret-cookie")
+}
+
+func TestStdLogger(t *testing.T) {
+	b := new(strings.Builder)
+	l := NewStdLogger(log.New(b, "", 0), LevelInfo)
+
+	l.Debug("skipped")
+	l.Info("request", "endpoint", EndpointDaily, "status", http.StatusOK)
+	l.Error("odd", "key")
+
+	assert.Equal(t, "level=INFO msg=\"request\" endpoint=/dailytrends status=200\n"+
+		"level=ERROR msg=\"odd\" !BADKEY=key\n", b.String())
+}
+
+func TestClientMiddleware(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer proxy", r.Header.Get("Proxy-Authorization"))
+		_, _ = w.Write([]byte(dailyPayload))
+	}))
+	defer srv.Close()
+
+	order := make([]string, 0)
+	auth := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			order = append(order, "auth")
+			req.Header.Set("Proxy-Authorization", "Bearer proxy")
+			return next(ctx, req)
+		}
+	}
+
+	ops := make([]Operation, 0)
+	rewrite := func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			order = append(order, "rewrite")
+			ops = append(ops, req.Operation)
+			assert.Equal(t, EndpointDaily, req.Endpoint)
+			assert.Equal(t, locUS, req.Params.Get(paramGeo))
+
+			resp, err := next(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			resp.Payload = bytes.Replace(resp.Payload, []byte("Golang"), []byte("Go"), 1)
+			return resp, nil
+		}
+	}
+
+	c := New(WithBaseURL(srv.URL), WithMiddleware(auth), WithMiddleware(rewrite))
+
+	resp, err := c.Daily(context.Background(), langEN, locUS)
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", resp[0].Title.Query)
+	assert.Equal(t, []string{"auth", "rewrite"}, order)
+	assert.Equal(t, []Operation{OpDaily}, ops)
+}
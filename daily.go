@@ -0,0 +1,288 @@
+package gogtrends
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// daily gets page of daily trends ending with ed date, empty ed is the most recent date.
+func (c *Client) daily(ctx context.Context, hl, loc, ed string) (*trendingSearchesDays, error) {
+	var args []map[string]string
+	if len(ed) > 0 {
+		args = append(args, map[string]string{paramED: ed})
+	}
+
+	out := new(dailyOut)
+	if err := c.trends(ctx, OpDaily, gDaily, hl, loc, out, args...); err != nil {
+		return nil, err
+	}
+
+	if out.Default == nil {
+		return new(trendingSearchesDays), nil
+	}
+
+	// tag searches with their day and numeric traffic
+	for _, d := range out.Default.Searches {
+		date, _ := time.Parse(dateLayout, d.Date)
+		for _, v := range d.Searches {
+			v.Date = date
+			v.FormattedDate = d.FormattedDate
+			v.Traffic, _ = ParseTraffic(v.FormattedTraffic)
+		}
+	}
+
+	return out.Default, nil
+}
+
+// DailyByDay gets page of daily trends grouped by days, descending, ending with ed date.
+// Zero ed is the most recent date, EndDateForNextRequest of page can be used as ed to get older days.
+func (c *Client) DailyByDay(ctx context.Context, hl, loc string, ed time.Time) (*DailyPage, error) {
+	var date string
+	if !ed.IsZero() {
+		date = ed.Format(dateLayout)
+	}
+
+	out, err := c.daily(ctx, hl, loc, date)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &DailyPage{Days: make([]*TrendingDay, 0, len(out.Searches)), RSSFeedPageURL: out.RSSFeedPageURL}
+	if len(out.EndDateForNextRequest) > 0 {
+		page.EndDateForNextRequest, err = time.Parse(dateLayout, out.EndDateForNextRequest)
+		if err != nil {
+			return nil, errors.Wrap(err, errParsing)
+		}
+	}
+
+	for _, v := range out.Searches {
+		page.Days = append(page.Days, newTrendingDay(v))
+	}
+
+	return page, nil
+}
+
+func newTrendingDay(d *trendingSearchDays) *TrendingDay {
+	date, _ := time.Parse(dateLayout, d.Date)
+
+	return &TrendingDay{Date: date, FormattedDate: d.FormattedDate, Searches: d.Searches}
+}
+
+// DailyOn gets daily trends of specific date, time of date is ignored.
+// Google Trends keeps daily trends for limited period, older dates return empty list.
+func (c *Client) DailyOn(ctx context.Context, hl, loc string, date time.Time) ([]*TrendingSearch, error) {
+	ed := date.Format(dateLayout)
+
+	out, err := c.daily(ctx, hl, loc, ed)
+	if err != nil {
+		return nil, err
+	}
+
+	searches := make([]*TrendingSearch, 0)
+	for _, v := range out.Searches {
+		if v.Date == ed {
+			searches = append(searches, v.Searches...)
+		}
+	}
+
+	return searches, nil
+}
+
+// DailyRange returns iterator over daily trends from `to` back to `from` date inclusive, one day at a time.
+// Time of dates is ignored, days missing in Google Trends are skipped.
+//
+//	it := client.DailyRange(ctx, "EN", "US", from, to)
+//	for it.Next() {
+//		day := it.Day()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+func (c *Client) DailyRange(ctx context.Context, hl, loc string, from, to time.Time) *DailyIterator {
+	return &DailyIterator{
+		c:    c,
+		ctx:  ctx,
+		hl:   hl,
+		loc:  loc,
+		from: from.Format(dateLayout),
+		ed:   to.Format(dateLayout),
+		seen: make(map[string]bool),
+	}
+}
+
+// DailyIterator walks through daily trends pages by date, descending.
+// Pages overlap, so every day is returned once. It is not safe for concurrent use.
+type DailyIterator struct {
+	c       *Client
+	ctx     context.Context
+	hl, loc string
+
+	// from is the oldest date to return, ed is an end date of next page, both in dateLayout
+	from, ed string
+
+	seen map[string]bool
+	days []*TrendingDay
+	day  *TrendingDay
+	err  error
+	done bool
+}
+
+// Next moves iterator to the next day, it returns false when range is over or error occurred.
+func (it *DailyIterator) Next() bool {
+	it.day = nil
+
+	for len(it.days) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+
+		it.fetch()
+	}
+
+	it.day, it.days = it.days[0], it.days[1:]
+
+	return true
+}
+
+// Day returns current day, it is valid after Next returned true.
+func (it *DailyIterator) Day() *TrendingDay {
+	return it.day
+}
+
+// Err returns error which stopped iteration.
+func (it *DailyIterator) Err() error {
+	return it.err
+}
+
+// fetch gets next page and buffers days in range, which were not returned before.
+func (it *DailyIterator) fetch() {
+	// dates in dateLayout are ordered as strings
+	if it.ed < it.from {
+		it.done = true
+		return
+	}
+
+	out, err := it.c.daily(it.ctx, it.hl, it.loc, it.ed)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	oldest := it.ed
+	for _, v := range out.Searches {
+		if v.Date < oldest {
+			oldest = v.Date
+		}
+
+		if v.Date > it.ed || v.Date < it.from || it.seen[v.Date] {
+			continue
+		}
+
+		it.seen[v.Date] = true
+		it.days = append(it.days, newTrendingDay(v))
+	}
+
+	next := out.EndDateForNextRequest
+	if len(next) == 0 || next >= it.ed {
+		// google didn't suggest next page, continue from the day before the oldest one
+		t, err := time.Parse(dateLayout, oldest)
+		if err != nil {
+			it.err = errors.Wrap(err, errParsing)
+			return
+		}
+
+		next = t.AddDate(0, 0, -1).Format(dateLayout)
+	}
+
+	// nothing is left in google history
+	if len(out.Searches) == 0 {
+		it.done = true
+	}
+
+	it.ed = next
+}
+
+// trafficUnits are multipliers of formatted traffic by unit suffix, in English and German.
+var trafficUnits = map[string]float64{
+	"K":   1e3,
+	"TSD": 1e3,
+	"M":   1e6,
+	"MIO": 1e6,
+	"B":   1e9,
+	"MRD": 1e9,
+}
+
+// ParseTraffic parses formatted traffic of daily trend, like "200K+", "2M+", "1,000+" or "2 Mio.+", into number of searches.
+// Without unit suffix "." and "," are digit grouping separators ("200.000+"), with it a single one is a decimal point ("1,5 Mio.+").
+// Ambiguous values, like "1.5+" or "1.000,5+", return error.
+func ParseTraffic(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	str = strings.TrimSuffix(str, "+")
+	str = strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "").Replace(str)
+
+	num := strings.TrimRightFunc(str, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+
+	mult := 1.0
+	if unit := strings.TrimSuffix(str[len(num):], "."); len(unit) > 0 {
+		m, ok := trafficUnits[unit]
+		if !ok {
+			return 0, errors.Errorf(errInvalidTrafficF, s)
+		}
+		mult = m
+	}
+
+	v, ok := parseTrafficNum(num, mult > 1)
+	if !ok {
+		return 0, errors.Errorf(errInvalidTrafficF, s)
+	}
+
+	return int64(math.Round(v * mult)), nil
+}
+
+// parseTrafficNum parses digits with "." or "," separators, they are a decimal point if decimal is true
+// and digit grouping separators otherwise.
+func parseTrafficNum(num string, decimal bool) (float64, bool) {
+	groups := strings.FieldsFunc(num, func(r rune) bool {
+		return r == '.' || r == ','
+	})
+
+	if len(groups) == 0 || len(groups)-1 != strings.Count(num, ".")+strings.Count(num, ",") {
+		return 0, false
+	}
+
+	for i, g := range groups {
+		for _, r := range g {
+			if r < '0' || r > '9' {
+				return 0, false
+			}
+		}
+
+		// groups are 3 digits, except the leading one
+		if !decimal && i > 0 && len(g) != 3 {
+			return 0, false
+		}
+	}
+
+	if decimal {
+		if len(groups) > 2 {
+			return 0, false
+		}
+
+		v, err := strconv.ParseFloat(strings.Join(groups, "."), 64)
+		return v, err == nil
+	}
+
+	// grouping separators are the same through the number
+	if strings.Contains(num, ".") && strings.Contains(num, ",") {
+		return 0, false
+	}
+
+	v, err := strconv.ParseFloat(strings.Join(groups, ""), 64)
+	return v, err == nil
+}
@@ -0,0 +1,171 @@
+package gogtrends
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/groovili/gogtrends/gogtrendstest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyOn(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+	srv.SetToday(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+
+	c := New(WithBaseURL(srv.URL))
+
+	searches, err := c.DailyOn(context.Background(), langEN, locUS, time.Date(2026, 10, 1, 15, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, searches, 2)
+	assert.Equal(t, "US trend 20261001", searches[0].Title.Query)
+}
+
+func TestDailyRange(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+	srv.SetToday(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+
+	c := New(WithBaseURL(srv.URL))
+	from := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+
+	dates := make([]string, 0)
+	it := c.DailyRange(context.Background(), langEN, locUS, from, srv.Today().AddDate(0, 0, -2))
+	for it.Next() {
+		day := it.Day()
+		assert.Equal(t, fmt.Sprintf("US trend %s", day.Date.Format(dateLayout)), day.Searches[0].Title.Query)
+		dates = append(dates, day.Date.Format(dateLayout))
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"20261014", "20261013", "20261012", "20261011", "20261010"}, dates)
+	assert.Equal(t, 3, srv.Requests(gogtrendstest.PathDaily))
+	assert.False(t, it.Next())
+}
+
+func TestDailyRangeOverlap(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		ed, _ := time.Parse(dateLayout, r.URL.Query().Get(paramED))
+
+		// pages overlap by one day and there is no next page hint
+		days := ""
+		for i := 0; i < 3; i++ {
+			d := ed.AddDate(0, 0, -i).Format(dateLayout)
+			if i > 0 {
+				days += ","
+			}
+			days += fmt.Sprintf(`{"date":"%s","trendingSearches":[{"title":{"query":"%s"}}]}`, d, d)
+		}
+
+		_, _ = w.Write([]byte(fmt.Sprintf(`)]}',{"default":{"trendingSearchesDays":[%s]}}`, days)))
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	dates := make([]string, 0)
+	it := c.DailyRange(context.Background(), langEN, locUS, from, from.AddDate(0, 0, 4))
+	for it.Next() {
+		dates = append(dates, it.Day().Searches[0].Title.Query)
+	}
+
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"20261005", "20261004", "20261003", "20261002", "20261001"}, dates)
+	assert.Equal(t, 2, requests)
+}
+
+func TestDailyRangeError(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+	srv.Script(gogtrendstest.PathDaily, gogtrendstest.ServerError(http.StatusForbidden))
+
+	c := New(WithBaseURL(srv.URL))
+
+	it := c.DailyRange(context.Background(), langEN, locUS, srv.Today().AddDate(0, 0, -3), srv.Today())
+	assert.False(t, it.Next())
+	assert.True(t, errors.Is(it.Err(), ErrRequestFailed))
+}
+
+func TestDailyByDay(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+	srv.SetToday(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC))
+
+	c := New(WithBaseURL(srv.URL))
+
+	page, err := c.DailyByDay(context.Background(), langEN, locUS, time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, page.Days, 2)
+	assert.Equal(t, srv.Today(), page.Days[0].Date)
+	assert.Equal(t, "Friday, October 16, 2026", page.Days[0].FormattedDate)
+	assert.Equal(t, srv.Today().AddDate(0, 0, -1), page.Days[1].Searches[0].Date)
+	assert.Equal(t, srv.Today().AddDate(0, 0, -2), page.EndDateForNextRequest)
+	assert.NotEmpty(t, page.RSSFeedPageURL)
+
+	next, err := c.DailyByDay(context.Background(), langEN, locUS, page.EndDateForNextRequest)
+	assert.NoError(t, err)
+	assert.Equal(t, page.EndDateForNextRequest, next.Days[0].Date)
+
+	daily, err := c.Daily(context.Background(), langEN, locUS)
+	assert.NoError(t, err)
+	assert.Equal(t, srv.Today(), daily[0].Date)
+	assert.Equal(t, "Thursday, October 15, 2026", daily[len(daily)-1].FormattedDate)
+}
+
+func TestParseTraffic(t *testing.T) {
+	cases := map[string]int64{
+		"200K+":  200000,
+		"2M+":    2000000,
+		"2.3K+":  2300,
+		"1,000+": 1000,
+		"500+":   500,
+		" 50k+ ": 50000,
+		"1B+":    1000000000,
+
+		// grouping separators and localized units
+		"200.000+":      200000,
+		"1.000.000+":    1000000,
+		"2 Mio.+":       2000000,
+		"1,5 Mio.+":     1500000,
+		"50\u00a0Tsd.+": 50000,
+		"1 Mrd.+":       1000000000,
+	}
+
+	for in, exp := range cases {
+		v, err := ParseTraffic(in)
+		assert.NoError(t, err, in)
+		assert.Equal(t, exp, v, in)
+	}
+
+	for _, in := range []string{"", "+", "many", "-5K+", "1.5+", "20.00+", "1.000,5+", "1,000.000+", "1.000,5K+", "5X+", ",5K+", "1e5+"} {
+		_, err := ParseTraffic(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestTrendingSearchFields(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+
+	daily, err := c.Daily(context.Background(), langEN, locUS)
+	assert.NoError(t, err)
+
+	s := daily[0]
+	assert.Equal(t, int64(200000), s.Traffic)
+	assert.NotEmpty(t, s.Title.ExploreLink)
+	assert.NotEmpty(t, s.ShareURL)
+	assert.NotEmpty(t, s.RelatedQueries[0].Query)
+	assert.NotEmpty(t, s.Articles[0].Image.ImageURL)
+	assert.NotEmpty(t, s.Articles[0].TimeAgo)
+	assert.Equal(t, int64(50000), daily[1].Traffic)
+}
@@ -0,0 +1,147 @@
+package gogtrends
+
+import (
+	"context"
+	"time"
+)
+
+// client is a default Client used by package level functions.
+var client = New()
+
+// Debug allows to see request-response details in standard logger.
+//
+// Deprecated: use New with WithLogger option.
+func Debug(debug bool) {
+	client.setLogger(debugLogger(debug))
+}
+
+func debugLogger(debug bool) Logger {
+	if debug {
+		return NewStdLogger(nil, LevelDebug)
+	}
+
+	return nopLogger{}
+}
+
+// TrendsCategories return list of available categories for Realtime method as [param]description map.
+func TrendsCategories() map[string]string {
+	return client.TrendsCategories()
+}
+
+// Daily gets daily trends descending ordered by days and articles corresponding to it.
+func Daily(ctx context.Context, hl, loc string) ([]*TrendingSearch, error) {
+	return client.Daily(ctx, hl, loc)
+}
+
+// DailyOn gets daily trends of specific date, time of date is ignored.
+func DailyOn(ctx context.Context, hl, loc string, date time.Time) ([]*TrendingSearch, error) {
+	return client.DailyOn(ctx, hl, loc, date)
+}
+
+// DailyByDay gets page of daily trends grouped by days, descending, ending with ed date.
+// Zero ed is the most recent date.
+func DailyByDay(ctx context.Context, hl, loc string, ed time.Time) (*DailyPage, error) {
+	return client.DailyByDay(ctx, hl, loc, ed)
+}
+
+// DailyRange returns iterator over daily trends from `to` back to `from` date inclusive, one day at a time.
+func DailyRange(ctx context.Context, hl, loc string, from, to time.Time) *DailyIterator {
+	return client.DailyRange(ctx, hl, loc, from, to)
+}
+
+// Realtime represents realtime trends with included articles and sources.
+func Realtime(ctx context.Context, hl, loc, cat string) ([]*TrendingStory, error) {
+	return client.Realtime(ctx, hl, loc, cat)
+}
+
+// RealtimeAll gets realtime stories beyond the first page, in order of trending board, limit <= 0 returns all stories.
+func RealtimeAll(ctx context.Context, hl, loc, cat string, limit int) ([]*TrendingStory, error) {
+	return client.RealtimeAll(ctx, hl, loc, cat, limit)
+}
+
+// DailyMulti gets daily trends for every location with bounded concurrency, failed locations are returned as MultiError.
+func DailyMulti(ctx context.Context, hl string, locs []string, opts *MultiOptions) (map[string][]*TrendingSearch, error) {
+	return client.DailyMulti(ctx, hl, locs, opts)
+}
+
+// RealtimeMulti gets realtime trends for every location with bounded concurrency, failed locations are returned as MultiError.
+func RealtimeMulti(ctx context.Context, hl string, locs []string, cat string, opts *MultiOptions) (map[string][]*TrendingStory, error) {
+	return client.RealtimeMulti(ctx, hl, locs, cat, opts)
+}
+
+// Watch polls realtime trends with interval and sends changes between polls to returned channel.
+func Watch(ctx context.Context, hl, loc, cat string, interval time.Duration, opts ...WatchOption) <-chan *WatchEvent {
+	return client.Watch(ctx, hl, loc, cat, interval, opts...)
+}
+
+// WatchDaily polls daily trends with interval and sends changes between polls to returned channel.
+func WatchDaily(ctx context.Context, hl, loc string, interval time.Duration, opts ...WatchOption) <-chan *WatchEvent {
+	return client.WatchDaily(ctx, hl, loc, interval, opts...)
+}
+
+// StoryDetails gets realtime story by its id with interest over time, articles and related queries.
+func StoryDetails(ctx context.Context, id, hl string) (*Story, error) {
+	return client.StoryDetails(ctx, id, hl)
+}
+
+// ExploreCategories gets available categories for explore and comparison and caches it in client.
+func ExploreCategories(ctx context.Context) (*ExploreCatTree, error) {
+	return client.ExploreCategories(ctx)
+}
+
+// ExploreLocations gets available locations for explore and comparison and caches it in client.
+func ExploreLocations(ctx context.Context) (*ExploreLocTree, error) {
+	return client.ExploreLocations(ctx)
+}
+
+// ExploreCategoriesLang gets categories with names in hl language and caches it in client until picker ttl expires.
+func ExploreCategoriesLang(ctx context.Context, hl string) (*ExploreCatTree, error) {
+	return client.ExploreCategoriesLang(ctx, hl)
+}
+
+// ExploreLocationsLang gets locations with names in hl language and caches it in client until picker ttl expires.
+func ExploreLocationsLang(ctx context.Context, hl string) (*ExploreLocTree, error) {
+	return client.ExploreLocationsLang(ctx, hl)
+}
+
+// RefreshCategories gets categories in hl language bypassing caches and updates client cache.
+func RefreshCategories(ctx context.Context, hl string) (*ExploreCatTree, error) {
+	return client.RefreshCategories(ctx, hl)
+}
+
+// RefreshLocations gets locations in hl language bypassing caches and updates client cache.
+func RefreshLocations(ctx context.Context, hl string) (*ExploreLocTree, error) {
+	return client.RefreshLocations(ctx, hl)
+}
+
+// InvalidatePickers removes cached categories and locations in all languages.
+func InvalidatePickers() {
+	client.InvalidatePickers()
+}
+
+// Explore list of widgets with tokens. Every widget
+// is related to specific method (`InterestOverTime`, `InterestOverLoc`, `RelatedSearches`, `Suggestions`)
+// and contains required token and request information.
+func Explore(ctx context.Context, r *ExploreRequest, hl string) (ExploreResponse, error) {
+	return client.Explore(ctx, r, hl)
+}
+
+// InterestOverTime as list of `Timeline` dots for chart.
+func InterestOverTime(ctx context.Context, w *ExploreWidget, hl string) ([]*Timeline, error) {
+	return client.InterestOverTime(ctx, w, hl)
+}
+
+// InterestByLocation as list of `GeoMap`, with geo codes and interest values.
+func InterestByLocation(ctx context.Context, w *ExploreWidget, hl string) ([]*GeoMap, error) {
+	return client.InterestByLocation(ctx, w, hl)
+}
+
+// Related topics or queries, list of `RankedKeyword`, supports two types of widgets.
+func Related(ctx context.Context, w *ExploreWidget, hl string) ([]*RankedKeyword, error) {
+	return client.Related(ctx, w, hl)
+}
+
+// Search words and topics related to keyword, list of `KeywordTopic`.
+func Search(ctx context.Context, word, hl string) ([]*KeywordTopic, error) {
+	return client.Search(ctx, word, hl)
+}
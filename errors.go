@@ -1,13 +1,25 @@
 package gogtrends
 
-import "github.com/pkg/errors"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
 
 const (
-	errParsing        = "failed to parse json"
-	errReqDataF       = "request data: code = %d, status = %s"
-	errInvalidRequest = "invalid request param"
-	errCreateRequest  = "failed to create request"
-	errDoRequest      = "failed to perform request"
+	errParsing         = "failed to parse json"
+	errReqDataF        = "request data: code = %d, status = %s, endpoint = %s"
+	errInvalidRequest  = "invalid request param"
+	errCreateRequest   = "failed to create request"
+	errDoRequest       = "failed to perform request"
+	errInvalidTrafficF = "invalid traffic value: %q"
+
+	// errBodyExcerptLen is a max length of response body stored in HTTPError
+	errBodyExcerptLen = 512
+	redacted          = "REDACTED"
 )
 
 var (
@@ -17,4 +29,70 @@ var (
 	ErrRequestFailed = errors.New("failed to perform http request")
 	// ErrInvalidWidgetType - provided widget is invalid or is used for another method
 	ErrInvalidWidgetType = errors.New("invalid widget type")
+	// ErrInvalidRequest - request doesn't pass client side validation, see ValidationError
+	ErrInvalidRequest = errors.New(errInvalidRequest)
+	// ErrInvalidLocation - location is not in ExploreLocations tree
+	ErrInvalidLocation = errors.New("invalid location param")
+	// ErrInvalidTimeRange - time range is not supported by Google Trends
+	ErrInvalidTimeRange = errors.New("invalid time range")
+	// ErrUnexpectedPayload - response body is not json, like consent or captcha html page
+	ErrUnexpectedPayload = errors.New("unexpected response payload")
 )
+
+// HTTPError - response status != 200 with request and response details.
+// It matches ErrRequestFailed, so errors.Is(err, ErrRequestFailed) works as before.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	// Endpoint is a requested API path, like EndpointExplore
+	Endpoint string
+	// Query is a request query with tokens removed
+	Query string
+	// Body is a beginning of response body
+	Body []byte
+	// RetryAfter is a delay requested by server with Retry-After header, 0 if not set
+	RetryAfter time.Duration
+}
+
+func newHTTPError(endpoint string, u *url.URL, resp *http.Response, body []byte) *HTTPError {
+	e := &HTTPError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Endpoint:   endpoint,
+		Query:      sanitizeQuery(u.Query()),
+		Body:       body,
+	}
+	e.RetryAfter, _ = retryAfter(resp.Header)
+
+	return e
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf(errReqDataF+": %s", e.StatusCode, e.Status, e.Endpoint, ErrRequestFailed)
+}
+
+// Unwrap allows to match HTTPError with ErrRequestFailed.
+func (e *HTTPError) Unwrap() error {
+	return ErrRequestFailed
+}
+
+// IsRateLimited checks if request failed because of too many requests (429) to Google Trends.
+func IsRateLimited(err error) bool {
+	var e *HTTPError
+	return errors.As(err, &e) && e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsServerError checks if request failed because of Google Trends internal error (5xx).
+func IsServerError(err error) bool {
+	var e *HTTPError
+	return errors.As(err, &e) && e.StatusCode >= http.StatusInternalServerError
+}
+
+// sanitizeQuery encodes query params with tokens redacted.
+func sanitizeQuery(q url.Values) string {
+	if _, ok := q[paramToken]; ok {
+		q.Set(paramToken, redacted)
+	}
+
+	return q.Encode()
+}
@@ -0,0 +1,163 @@
+package gogtrends
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// flightCall is an in-flight request, which result is shared by all callers.
+type flightCall struct {
+	done chan struct{}
+	resp *Response
+	err  error
+
+	// waiters is a number of callers waiting for result, request is canceled when all of them are gone
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// flightGroup collapses concurrent identical requests into one.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{calls: make(map[string]*flightCall)}
+}
+
+// do calls fn once for all concurrent callers with the same key. Every caller waits for result until its own
+// context is done, fn runs with context of the first caller without its cancellation and deadline,
+// so it's canceled only when all callers stopped waiting.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) (*Response, error)) (*Response, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		call.waiters++
+		g.mu.Unlock()
+
+		return g.wait(ctx, key, call)
+	}
+
+	fctx, cancel := context.WithCancel(detachedContext{ctx})
+	call := &flightCall{done: make(chan struct{}), waiters: 1, cancel: cancel}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	go func() {
+		resp, err := fn(fctx)
+
+		g.mu.Lock()
+		if g.calls[key] == call {
+			delete(g.calls, key)
+		}
+		waiters := call.waiters
+		g.mu.Unlock()
+
+		// streamed body can be read by one caller only, it's buffered for followers
+		if err == nil && resp.body != nil && waiters > 1 {
+			if err = resp.buffer(); err != nil {
+				resp = nil
+			}
+		}
+
+		// request context lives until streamed body is closed
+		if err == nil && resp.body != nil {
+			resp.body = &cancelBody{ReadCloser: resp.body, cancel: cancel}
+		} else {
+			cancel()
+		}
+
+		g.mu.Lock()
+		call.resp, call.err = resp, err
+		close(call.done)
+		abandoned := call.waiters == 0
+		g.mu.Unlock()
+
+		if abandoned && resp != nil {
+			resp.close()
+		}
+	}()
+
+	return g.wait(ctx, key, call)
+}
+
+// wait blocks until call is done or ctx is canceled, the last leaving caller cancels request.
+func (g *flightGroup) wait(ctx context.Context, key string, call *flightCall) (*Response, error) {
+	select {
+	case <-call.done:
+		return call.shared()
+	case <-ctx.Done():
+	}
+
+	g.mu.Lock()
+	call.waiters--
+	if call.waiters == 0 {
+		select {
+		case <-call.done:
+			// nobody reads streamed body of finished call
+			if call.resp != nil {
+				call.resp.close()
+			}
+		default:
+			// next caller starts new request instead of joining canceled one
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			call.cancel()
+		}
+	}
+	g.mu.Unlock()
+
+	return nil, ctx.Err()
+}
+
+// shared returns copy of response, so callers can replace its fields independently.
+// Payload is shared and should not be modified in place, streamed body is given to the only waiting caller.
+func (f *flightCall) shared() (*Response, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	resp := *f.resp
+	return &resp, nil
+}
+
+// cancelBody cancels request context when streamed body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+
+	return err
+}
+
+// detachedContext keeps values of parent context, but it's never canceled with parent.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
+
+// deduplicated is a middleware which collapses identical in-flight requests by url, query and headers.
+func (c *Client) deduplicated(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		key := req.URL.Host + req.URL.Path + "?" + req.Params.Encode() + "\n" + url.Values(req.Header).Encode()
+
+		return c.flights.do(ctx, key, func(ctx context.Context) (*Response, error) {
+			return next(ctx, req)
+		})
+	}
+}
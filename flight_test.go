@@ -0,0 +1,170 @@
+package gogtrends
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientDeduplication(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		_, _ = w.Write([]byte(`)]}'{"name":"All categories","id":0,"children":[{"name":"Programming","id":31}]}`))
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+
+	wg := new(sync.WaitGroup)
+	wg.Add(concurrentGoroutinesNum)
+	for i := 0; i < concurrentGoroutinesNum; i++ {
+		go func() {
+			defer wg.Done()
+
+			cats, err := c.ExploreCategories(context.Background())
+			assert.NoError(t, err)
+			assert.Len(t, cats.Children, 1)
+		}()
+	}
+
+	waitFlights(t, c.flights, concurrentGoroutinesNum)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// requests with different headers aren't collapsed
+	entered := make(chan struct{}, 2)
+	block := make(chan struct{})
+	h := c.deduplicated(func(ctx context.Context, req *Request) (*Response, error) {
+		entered <- struct{}{}
+		<-block
+		return &Response{}, nil
+	})
+
+	u, _ := url.Parse(srv.URL)
+	for _, lang := range []string{"en", "de"} {
+		req := &Request{URL: u, Params: make(url.Values), Header: http.Header{"Accept-Language": {lang}}}
+		go func() {
+			_, _ = h(context.Background(), req)
+		}()
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-entered:
+		case <-time.After(time.Second):
+			t.Fatal("request with other headers must not be shared")
+		}
+	}
+	close(block)
+}
+
+func TestFlightGroup(t *testing.T) {
+	g := newFlightGroup()
+	started := make(chan struct{})
+	block := make(chan struct{})
+	fctx := make(chan context.Context, 1)
+	fn := func(ctx context.Context) (*Response, error) {
+		fctx <- ctx
+		close(started)
+		<-block
+		return &Response{StatusCode: http.StatusOK}, ctx.Err()
+	}
+
+	// follower stops waiting with its own context
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() {
+		_, err := g.do(leaderCtx, "key", fn)
+		leader <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := g.do(ctx, "key", func(context.Context) (*Response, error) {
+		t.Error("request must be shared")
+		return nil, nil
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// cancellation of the first caller doesn't fail followers
+	follower := make(chan *Response, 1)
+	go func() {
+		resp, err := g.do(context.Background(), "key", func(context.Context) (*Response, error) {
+			t.Error("request must be shared")
+			return nil, nil
+		})
+		assert.NoError(t, err)
+		follower <- resp
+	}()
+	waitFlights(t, g, 2)
+
+	cancelLeader()
+	assert.Equal(t, context.Canceled, <-leader)
+	assert.NoError(t, (<-fctx).Err())
+
+	close(block)
+	select {
+	case resp := <-follower:
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	case <-time.After(time.Second):
+		t.Fatal("follower didn't get response")
+	}
+
+	// request is canceled when all callers are gone
+	started = make(chan struct{})
+	block = make(chan struct{})
+	defer close(block)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		_, _ = g.do(ctx, "key", fn)
+	}()
+	<-started
+	cancel()
+
+	select {
+	case <-(<-fctx).Done():
+	case <-time.After(time.Second):
+		t.Fatal("abandoned request must be canceled")
+	}
+}
+
+// waitFlights waits until n callers are waiting for in-flight requests of g.
+func waitFlights(t *testing.T, g *flightGroup, n int) {
+	t.Helper()
+
+	timeout := time.After(time.Second)
+	for {
+		g.mu.Lock()
+		waiters := 0
+		for _, call := range g.calls {
+			waiters += call.waiters
+		}
+		g.mu.Unlock()
+
+		if waiters == n {
+			return
+		}
+
+		select {
+		case <-timeout:
+			t.Fatalf("%d callers are waiting, want %d", waiters, n)
+		default:
+			runtime.Gosched()
+		}
+	}
+}
@@ -3,7 +3,7 @@ module github.com/groovili/gogtrends
 go 1.14
 
 require (
-	github.com/json-iterator/go v1.1.10
+	github.com/json-iterator/go v1.1.12
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.7.0
 )
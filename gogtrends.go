@@ -10,36 +10,21 @@ import (
 	"github.com/pkg/errors"
 )
 
-var client = newGClient()
-
-// Debug allows to see request-response details.
-func Debug(debug bool) {
-	client.debug = debug
-}
-
 // TrendsCategories return list of available categories for Realtime method as [param]description map.
-func TrendsCategories() map[string]string {
-	return client.trendsCats
+func (c *Client) TrendsCategories() map[string]string {
+	return c.trendsCats
 }
 
 // Daily gets daily trends descending ordered by days and articles corresponding to it.
-func Daily(ctx context.Context, hl, loc string) ([]*TrendingSearch, error) {
-	data, err := client.trends(ctx, gAPI+gDaily, hl, loc)
+func (c *Client) Daily(ctx context.Context, hl, loc string) ([]*TrendingSearch, error) {
+	out, err := c.daily(ctx, hl, loc, "")
 	if err != nil {
 		return nil, err
 	}
 
-	// google api returns not valid json :(
-	str := strings.Replace(data, ")]}',", "", 1)
-
-	out := new(dailyOut)
-	if err := client.unmarshal(str, out); err != nil {
-		return nil, err
-	}
-
 	// split searches by days together
 	searches := make([]*TrendingSearch, 0)
-	for _, v := range out.Default.Searches {
+	for _, v := range out.Searches {
 		searches = append(searches, v.Searches...)
 	}
 
@@ -47,91 +32,96 @@ func Daily(ctx context.Context, hl, loc string) ([]*TrendingSearch, error) {
 }
 
 // Realtime represents realtime trends with included articles and sources.
-func Realtime(ctx context.Context, hl, loc, cat string) ([]*TrendingStory, error) {
-	if !client.validateCategory(cat) {
-		return nil, ErrInvalidCategory
-	}
-
-	data, err := client.trends(ctx, gAPI+gRealtime, hl, loc, map[string]string{paramCat: cat})
+func (c *Client) Realtime(ctx context.Context, hl, loc, cat string) ([]*TrendingStory, error) {
+	out, err := c.realtime(ctx, hl, loc, cat)
 	if err != nil {
 		return nil, err
 	}
 
-	// google api returns not valid json :(
-	str := strings.Replace(data, ")]}'", "", 1)
-
-	out := new(realtimeOut)
-	if err := client.unmarshal(str, out); err != nil {
-		return nil, err
-	}
-
 	return out.StorySummaries.TrendingStories, nil
 }
 
 // ExploreCategories gets available categories for explore and comparison and caches it in client.
-func ExploreCategories(ctx context.Context) (*ExploreCatTree, error) {
-	if cats := client.getCategories(); cats != nil {
-		return cats, nil
-	}
-
-	u, _ := url.Parse(gAPI + gSCategories)
+func (c *Client) ExploreCategories(ctx context.Context) (*ExploreCatTree, error) {
+	return c.ExploreCategoriesLang(ctx, "")
+}
 
-	b, err := client.do(ctx, u)
-	if err != nil {
-		return nil, err
+// ExploreCategoriesLang gets categories with names in hl language and caches it in client until picker ttl expires.
+// Empty hl is a Google Trends default language.
+func (c *Client) ExploreCategoriesLang(ctx context.Context, hl string) (*ExploreCatTree, error) {
+	if cats, ok := c.categories.get(hl); ok {
+		return cats.(*ExploreCatTree), nil
 	}
 
-	// google api returns not valid json :(
-	str := strings.Replace(string(b), ")]}'", "", 1)
+	return c.RefreshCategories(ctx, hl)
+}
 
+// RefreshCategories gets categories in hl language bypassing caches and updates client cache.
+func (c *Client) RefreshCategories(ctx context.Context, hl string) (*ExploreCatTree, error) {
 	out := new(ExploreCatTree)
-	if err := client.unmarshal(str, out); err != nil {
+	if err := c.picker(ctx, OpExploreCategories, gSCategories, hl, out); err != nil {
 		return nil, err
 	}
 
 	// cache in client
-	client.setCategories(out)
+	c.categories.set(hl, out)
 
 	return out, nil
 }
 
 // ExploreLocations gets available locations for explore and comparison and caches it in client.
-func ExploreLocations(ctx context.Context) (*ExploreLocTree, error) {
-	if locs := client.getLocations(); locs != nil {
-		return locs, nil
-	}
-
-	u, _ := url.Parse(gAPI + gSGeo)
+func (c *Client) ExploreLocations(ctx context.Context) (*ExploreLocTree, error) {
+	return c.ExploreLocationsLang(ctx, "")
+}
 
-	b, err := client.do(ctx, u)
-	if err != nil {
-		return nil, err
+// ExploreLocationsLang gets locations with names in hl language and caches it in client until picker ttl expires.
+// Empty hl is a Google Trends default language.
+func (c *Client) ExploreLocationsLang(ctx context.Context, hl string) (*ExploreLocTree, error) {
+	if locs, ok := c.locations.get(hl); ok {
+		return locs.(*ExploreLocTree), nil
 	}
 
-	// google api returns not valid json :(
-	str := strings.Replace(string(b), ")]}'", "", 1)
+	return c.RefreshLocations(ctx, hl)
+}
 
+// RefreshLocations gets locations in hl language bypassing caches and updates client cache.
+func (c *Client) RefreshLocations(ctx context.Context, hl string) (*ExploreLocTree, error) {
 	out := new(ExploreLocTree)
-	if err := client.unmarshal(str, out); err != nil {
+	if err := c.picker(ctx, OpExploreLocations, gSGeo, hl, out); err != nil {
 		return nil, err
 	}
 
 	// cache in client
-	client.setLocations(out)
+	c.locations.set(hl, out)
 
 	return out, nil
 }
 
+// InvalidatePickers removes cached categories and locations in all languages.
+func (c *Client) InvalidatePickers() {
+	c.categories.invalidate()
+	c.locations.invalidate()
+}
+
 // Explore list of widgets with tokens. Every widget
 // is related to specific method (`InterestOverTime`, `InterestOverLoc`, `RelatedSearches`, `Suggestions`)
 // and contains required token and request information.
-func Explore(ctx context.Context, r *ExploreRequest, hl string) (ExploreResponse, error) {
+func (c *Client) Explore(ctx context.Context, r *ExploreRequest, hl string) (ExploreResponse, error) {
 	// hook for using incorrect `time` request (backward compatibility)
 	for _, r := range r.ComparisonItems {
 		r.Time = strings.ReplaceAll(r.Time, "+", " ")
 	}
 
-	u, _ := url.Parse(gAPI + gSExplore)
+	if c.validate {
+		if err := c.validateCached(r); err != nil {
+			return nil, err
+		}
+	}
+
+	u, err := c.url(gSExplore)
+	if err != nil {
+		return nil, err
+	}
 
 	p := make(url.Values)
 	p.Set(paramTZ, "0")
@@ -144,18 +134,15 @@ func Explore(ctx context.Context, r *ExploreRequest, hl string) (ExploreResponse
 	}
 
 	p.Set(paramReq, mReq)
-	u.RawQuery = p.Encode()
-
-	b, err := client.do(ctx, u)
-	if err != nil {
-		return nil, err
-	}
-
-	// google api returns not valid json :(
-	str := strings.Replace(string(b), ")]}'", "", 1)
 
 	out := new(exploreOut)
-	if err := client.unmarshal(str, out); err != nil {
+	if err := c.do(ctx, &Request{
+		Operation: OpExplore,
+		Endpoint:  gSExplore,
+		URL:       u,
+		Params:    p,
+		Explore:   r,
+	}, out); err != nil {
 		return nil, err
 	}
 
@@ -163,21 +150,24 @@ func Explore(ctx context.Context, r *ExploreRequest, hl string) (ExploreResponse
 }
 
 // InterestOverTime as list of `Timeline` dots for chart.
-func InterestOverTime(ctx context.Context, w *ExploreWidget, hl string) ([]*Timeline, error) {
+func (c *Client) InterestOverTime(ctx context.Context, w *ExploreWidget, hl string) ([]*Timeline, error) {
 	if !strings.HasPrefix(w.ID, string(IntOverTimeWidgetID)) {
 		return nil, ErrInvalidWidgetType
 	}
 
-	u, _ := url.Parse(gAPI + gSIntOverTime)
+	u, err := c.url(gSIntOverTime)
+	if err != nil {
+		return nil, err
+	}
 
 	p := make(url.Values)
 	p.Set(paramTZ, "0")
 	p.Set(paramHl, hl)
 	p.Set(paramToken, w.Token)
 
-	for i, v := range w.Request.CompItem {
+	for _, v := range w.Request.CompItem {
 		if len(v.Geo) == 0 {
-			w.Request.CompItem[i].Geo[""] = ""
+			v.Geo = map[string]string{"": ""}
 		}
 	}
 
@@ -188,18 +178,15 @@ func InterestOverTime(ctx context.Context, w *ExploreWidget, hl string) ([]*Time
 	}
 
 	p.Set(paramReq, mReq)
-	u.RawQuery = p.Encode()
-
-	b, err := client.do(ctx, u)
-	if err != nil {
-		return nil, err
-	}
-
-	// google api returns not valid json :(
-	str := strings.Replace(string(b), ")]}',", "", 1)
 
 	out := new(multilineOut)
-	if err := client.unmarshal(str, out); err != nil {
+	if err := c.do(ctx, &Request{
+		Operation: OpInterestOverTime,
+		Endpoint:  gSIntOverTime,
+		URL:       u,
+		Params:    p,
+		Widget:    w,
+	}, out); err != nil {
 		return nil, err
 	}
 
@@ -207,12 +194,15 @@ func InterestOverTime(ctx context.Context, w *ExploreWidget, hl string) ([]*Time
 }
 
 // InterestByLocation as list of `GeoMap`, with geo codes and interest values.
-func InterestByLocation(ctx context.Context, w *ExploreWidget, hl string) ([]*GeoMap, error) {
+func (c *Client) InterestByLocation(ctx context.Context, w *ExploreWidget, hl string) ([]*GeoMap, error) {
 	if !strings.HasPrefix(w.ID, string(IntOverRegionID)) {
 		return nil, ErrInvalidWidgetType
 	}
 
-	u, _ := url.Parse(gAPI + gSIntOverReg)
+	u, err := c.url(gSIntOverReg)
+	if err != nil {
+		return nil, err
+	}
 
 	p := make(url.Values)
 	p.Set(paramTZ, "0")
@@ -230,18 +220,15 @@ func InterestByLocation(ctx context.Context, w *ExploreWidget, hl string) ([]*Ge
 	}
 
 	p.Set(paramReq, mReq)
-	u.RawQuery = p.Encode()
-
-	b, err := client.do(ctx, u)
-	if err != nil {
-		return nil, err
-	}
-
-	// google api returns not valid json :(
-	str := strings.Replace(string(b), ")]}',", "", 1)
 
 	out := new(geoOut)
-	if err := client.unmarshal(str, out); err != nil {
+	if err := c.do(ctx, &Request{
+		Operation: OpInterestByLocation,
+		Endpoint:  gSIntOverReg,
+		URL:       u,
+		Params:    p,
+		Widget:    w,
+	}, out); err != nil {
 		return nil, err
 	}
 
@@ -249,12 +236,15 @@ func InterestByLocation(ctx context.Context, w *ExploreWidget, hl string) ([]*Ge
 }
 
 // Related topics or queries, list of `RankedKeyword`, supports two types of widgets.
-func Related(ctx context.Context, w *ExploreWidget, hl string) ([]*RankedKeyword, error) {
+func (c *Client) Related(ctx context.Context, w *ExploreWidget, hl string) ([]*RankedKeyword, error) {
 	if !strings.HasPrefix(w.ID, string(RelatedQueriesID)) && !strings.HasPrefix(w.ID, string(RelatedTopicsID)) {
 		return nil, ErrInvalidWidgetType
 	}
 
-	u, _ := url.Parse(gAPI + gSRelated)
+	u, err := c.url(gSRelated)
+	if err != nil {
+		return nil, err
+	}
 
 	p := make(url.Values)
 	p.Set(paramTZ, "0")
@@ -262,7 +252,7 @@ func Related(ctx context.Context, w *ExploreWidget, hl string) ([]*RankedKeyword
 	p.Set(paramToken, w.Token)
 
 	if len(w.Request.Restriction.Geo) == 0 {
-		w.Request.Restriction.Geo[""] = ""
+		w.Request.Restriction.Geo = map[string]string{"": ""}
 	}
 
 	// marshal request for query param
@@ -272,18 +262,15 @@ func Related(ctx context.Context, w *ExploreWidget, hl string) ([]*RankedKeyword
 	}
 
 	p.Set(paramReq, mReq)
-	u.RawQuery = p.Encode()
-
-	b, err := client.do(ctx, u)
-	if err != nil {
-		return nil, err
-	}
-
-	// google api returns not valid json :(
-	str := strings.Replace(string(b), ")]}',", "", 1)
 
 	out := new(relatedOut)
-	if err := client.unmarshal(str, out); err != nil {
+	if err := c.do(ctx, &Request{
+		Operation: OpRelated,
+		Endpoint:  gSRelated,
+		URL:       u,
+		Params:    p,
+		Widget:    w,
+	}, out); err != nil {
 		return nil, err
 	}
 
@@ -296,27 +283,19 @@ func Related(ctx context.Context, w *ExploreWidget, hl string) ([]*RankedKeyword
 	return keywords, nil
 }
 
-// Related topics or queries, list of `RankedKeyword`, supports two types of widgets.
-func Search(ctx context.Context, word, hl string) ([]*KeywordTopic, error) {
-	req := fmt.Sprintf("%s%s/%s", gAPI, gSAutocomplete, url.QueryEscape(word))
-	u, _ := url.Parse(req)
-
-	p := make(url.Values)
-	p.Set(paramTZ, "0")
-	p.Set(paramHl, hl)
-
-	u.RawQuery = p.Encode()
-
-	b, err := client.do(ctx, u)
+// Search words and topics related to keyword, list of `KeywordTopic`.
+func (c *Client) Search(ctx context.Context, word, hl string) ([]*KeywordTopic, error) {
+	u, err := c.url(fmt.Sprintf("%s/%s", gSAutocomplete, url.QueryEscape(word)))
 	if err != nil {
 		return nil, err
 	}
 
-	// google api returns not valid json :(
-	str := strings.Replace(string(b), ")]}',", "", 1)
+	p := make(url.Values)
+	p.Set(paramTZ, "0")
+	p.Set(paramHl, hl)
 
 	out := new(searchOut)
-	if err := client.unmarshal(str, out); err != nil {
+	if err := c.do(ctx, &Request{Operation: OpSearch, Endpoint: gSAutocomplete, URL: u, Params: p}, out); err != nil {
 		return nil, err
 	}
 
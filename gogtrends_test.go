@@ -3,11 +3,16 @@ package gogtrends
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/groovili/gogtrends/gogtrendstest"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -19,15 +24,68 @@ const (
 	concurrentGoroutinesNum = 10
 	loadTestNum             = 20 // changed for test speed up
 	catProgramming          = 31
+
+	// fixturesDir has recorded responses, tests replay them if directory exists
+	fixturesDir = "testdata/fixtures"
+	apiAddr     = "trends.google.com:443"
+)
+
+var (
+	replay   bool
+	apiOnce  sync.Once
+	apiError error
 )
 
+// TestMain switches default client to recorded fixtures when they exist or are being recorded,
+// see gogtrendstest.NewTransport.
+func TestMain(m *testing.M) {
+	if _, err := os.Stat(fixturesDir); err == nil || len(os.Getenv(gogtrendstest.EnvRecord)) > 0 {
+		replay = true
+		client = New(WithHTTPClient(&http.Client{Transport: gogtrendstest.NewTransport(fixturesDir)}))
+	}
+
+	os.Exit(m.Run())
+}
+
+// requireAPI skips test, which calls Google Trends API, if there are no fixtures and API is not reachable.
+func requireAPI(t *testing.T) {
+	t.Helper()
+
+	if replay {
+		return
+	}
+
+	apiOnce.Do(func() {
+		conn, err := net.DialTimeout("tcp", apiAddr, 3*time.Second)
+		if err == nil {
+			conn.Close()
+		}
+		apiError = err
+	})
+
+	if apiError != nil {
+		t.Skipf("Google Trends API is not reachable: %v", apiError)
+	}
+}
+
 func TestDebug(t *testing.T) {
 	Debug(true)
-	assert.True(t, client.debug)
+	assert.IsType(t, &StdLogger{}, client.log())
 	Debug(false)
+	assert.IsType(t, nopLogger{}, client.log())
+}
+
+func TestNew(t *testing.T) {
+	c := New(WithLogger(NewStdLogger(nil, LevelDebug)))
+	assert.IsType(t, &StdLogger{}, c.log())
+	assert.IsType(t, nopLogger{}, client.log())
+	assert.NotSame(t, client, c)
+	assert.Equal(t, client.TrendsCategories(), c.TrendsCategories())
 }
 
 func TestDailyTrending(t *testing.T) {
+	requireAPI(t)
+
 	_, err := Daily(context.Background(), "unknown", "Kashyyyk")
 	assert.Error(t, err)
 
@@ -37,6 +95,8 @@ func TestDailyTrending(t *testing.T) {
 }
 
 func TestRealtimeTrending(t *testing.T) {
+	requireAPI(t)
+
 	categories := TrendsCategories()
 	assert.True(t, len(categories) > 0)
 	_, ok := categories[catAll]
@@ -51,6 +111,8 @@ func TestRealtimeTrending(t *testing.T) {
 }
 
 func TestRealtimeTrendingConcurrent(t *testing.T) {
+	requireAPI(t)
+
 	wg := new(sync.WaitGroup)
 	wg.Add(concurrentGoroutinesNum)
 	for i := 0; i < concurrentGoroutinesNum; i++ {
@@ -71,6 +133,8 @@ func TestRealtimeTrendingConcurrent(t *testing.T) {
 }
 
 func TestExploreCategories(t *testing.T) {
+	requireAPI(t)
+
 	wg := new(sync.WaitGroup)
 	wg.Add(concurrentGoroutinesNum)
 	for i := 0; i < concurrentGoroutinesNum; i++ {
@@ -85,6 +149,8 @@ func TestExploreCategories(t *testing.T) {
 }
 
 func TestExploreLocations(t *testing.T) {
+	requireAPI(t)
+
 	wg := new(sync.WaitGroup)
 	wg.Add(concurrentGoroutinesNum)
 	for i := 0; i < concurrentGoroutinesNum; i++ {
@@ -99,6 +165,8 @@ func TestExploreLocations(t *testing.T) {
 }
 
 func TestExplore(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -115,6 +183,8 @@ func TestExplore(t *testing.T) {
 }
 
 func TestInterestOverTime(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -139,6 +209,8 @@ func TestInterestOverTime(t *testing.T) {
 }
 
 func TestInterestByLocation(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -163,6 +235,8 @@ func TestInterestByLocation(t *testing.T) {
 }
 
 func TestInterestByLocationConcurrent(t *testing.T) {
+	requireAPI(t)
+
 	wg := new(sync.WaitGroup)
 
 	wg.Add(concurrentGoroutinesNum)
@@ -195,6 +269,8 @@ func TestInterestByLocationConcurrent(t *testing.T) {
 }
 
 func TestRelated(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -223,6 +299,8 @@ func TestRelated(t *testing.T) {
 }
 
 func TestLoadDaily(t *testing.T) {
+	requireAPI(t)
+
 	res := make([][]*TrendingSearch, loadTestNum)
 	errors := make([]error, loadTestNum)
 	for i := 0; i < loadTestNum; i++ {
@@ -239,6 +317,8 @@ func TestLoadDaily(t *testing.T) {
 }
 
 func TestLoadRealtime(t *testing.T) {
+	requireAPI(t)
+
 	res := make([][]*TrendingStory, loadTestNum)
 	errors := make([]error, loadTestNum)
 	for i := 0; i < loadTestNum; i++ {
@@ -255,6 +335,8 @@ func TestLoadRealtime(t *testing.T) {
 }
 
 func TestLoadOverTime(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -286,6 +368,8 @@ func TestLoadOverTime(t *testing.T) {
 }
 
 func TestLoadByLocation(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -317,6 +401,8 @@ func TestLoadByLocation(t *testing.T) {
 }
 
 func TestCompareInterest(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -358,6 +444,8 @@ func TestCompareInterest(t *testing.T) {
 }
 
 func TestCompareInterestConcurrent(t *testing.T) {
+	requireAPI(t)
+
 	wg := new(sync.WaitGroup)
 	wg.Add(concurrentGoroutinesNum)
 	for i := 0; i < concurrentGoroutinesNum; i++ {
@@ -408,6 +496,8 @@ func TestCompareInterestConcurrent(t *testing.T) {
 }
 
 func TestMultipleComparisonItems(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -467,6 +557,8 @@ func TestMultipleComparisonItems(t *testing.T) {
 }
 
 func TestExploreSort(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -515,6 +607,8 @@ func TestExploreSort(t *testing.T) {
 }
 
 func TestExploreGetWidgetsByOrder(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -556,6 +650,8 @@ func TestExploreGetWidgetsByOrder(t *testing.T) {
 }
 
 func TestExploreGetWidgetsByType(t *testing.T) {
+	requireAPI(t)
+
 	req := &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
 			{
@@ -589,6 +685,8 @@ func TestExploreGetWidgetsByType(t *testing.T) {
 }
 
 func TestAutocomplete(t *testing.T) {
+	requireAPI(t)
+
 	explore, err := Search(context.Background(), "Golang", langEN)
 	assert.NoError(t, err)
 
@@ -605,6 +703,8 @@ func TestAutocomplete(t *testing.T) {
 }
 
 func TestComparisonItemWithStartAndEndTime(t *testing.T) {
+	requireAPI(t)
+
 	ctx := context.Background()
 	explore, err := Explore(ctx, &ExploreRequest{
 		ComparisonItems: []*ComparisonItem{
@@ -0,0 +1,194 @@
+// Package gogtrendstest provides utilities for deterministic offline tests of code which uses gogtrends:
+// record and replay transport for real Google Trends responses and in-process fake Google Trends server.
+package gogtrendstest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+const (
+	// EnvRecord enables recording mode of NewTransport when set to non-empty value
+	EnvRecord = "GOGTRENDS_RECORD"
+
+	fixtureExt = ".json"
+
+	errReadFixture  = "failed to read fixture"
+	errWriteFixture = "failed to write fixture"
+)
+
+// sessionHeaders are response headers with session cookies, they are not stored in fixtures.
+var sessionHeaders = []string{"Set-Cookie", "Set-Cookie2"}
+
+// ErrFixtureNotFound - replayed request has no recorded fixture
+var ErrFixtureNotFound = errors.New("fixture not found")
+
+// Fixture is a recorded request-response pair. Body is stored as is, including anti-XSSI prefix.
+type Fixture struct {
+	URL        string      `json:"url"`
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+// NormalizeURL returns url key of request without scheme and with sorted query params,
+// so requests with same params in different order match the same fixture.
+func NormalizeURL(r *http.Request) string {
+	return r.URL.Host + r.URL.EscapedPath() + "?" + r.URL.Query().Encode()
+}
+
+// fixtureName is readable endpoint path with hash of normalized url.
+func fixtureName(key string) string {
+	path := key
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		path = path[i+1:]
+	}
+
+	if i := strings.Index(path, "?"); i >= 0 {
+		path = path[:i]
+	}
+
+	path = strings.NewReplacer("/", "_", "%", "_", ".", "_").Replace(path)
+	sum := sha256.Sum256([]byte(key))
+
+	return path + "-" + hex.EncodeToString(sum[:8]) + fixtureExt
+}
+
+// Recorder is a http.RoundTripper which performs requests with next transport and stores
+// every response as a fixture in directory. Session cookies are not recorded, so fixtures can be committed.
+type Recorder struct {
+	dir  string
+	next http.RoundTripper
+	mu   sync.Mutex
+}
+
+// NewRecorder creates recorder to dir, if next is nil http.DefaultTransport is used.
+func NewRecorder(dir string, next http.RoundTripper) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &Recorder{dir: dir, next: next}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+
+	// client still gets cookies, only fixture is cleaned
+	header := resp.Header.Clone()
+	for _, v := range sessionHeaders {
+		header.Del(v)
+	}
+
+	key := NormalizeURL(req)
+	f := &Fixture{
+		URL:        key,
+		StatusCode: resp.StatusCode,
+		Header:     header,
+		Body:       string(b),
+	}
+
+	if err := r.write(fixtureName(key), f); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) write(name string, f *Fixture) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return errors.Wrap(err, errWriteFixture)
+	}
+
+	b, err := jsoniter.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, errWriteFixture)
+	}
+
+	return errors.Wrap(ioutil.WriteFile(filepath.Join(r.dir, name), b, 0o644), errWriteFixture)
+}
+
+// Replayer is a http.RoundTripper which answers with recorded fixtures matched by normalized url.
+// Request without fixture fails with ErrFixtureNotFound.
+type Replayer struct {
+	dir string
+}
+
+// NewReplayer creates replayer of fixtures from dir.
+func NewReplayer(dir string) *Replayer {
+	return &Replayer{dir: dir}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+
+	key := NormalizeURL(req)
+
+	b, err := ioutil.ReadFile(filepath.Join(r.dir, fixtureName(key)))
+	if os.IsNotExist(err) {
+		return nil, errors.Wrap(ErrFixtureNotFound, key)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errReadFixture)
+	}
+
+	f := new(Fixture)
+	if err := jsoniter.Unmarshal(b, f); err != nil {
+		return nil, errors.Wrap(err, errReadFixture)
+	}
+
+	header := f.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.StatusCode, http.StatusText(f.StatusCode)),
+		StatusCode:    f.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(f.Body)),
+		ContentLength: int64(len(f.Body)),
+		Request:       req,
+	}, nil
+}
+
+// NewTransport returns Recorder to real Google Trends if EnvRecord environment variable is set, Replayer otherwise.
+// It allows to refresh fixtures with `GOGTRENDS_RECORD=1 go test ./...` and run tests offline by default.
+func NewTransport(dir string) http.RoundTripper {
+	if len(os.Getenv(EnvRecord)) > 0 {
+		return NewRecorder(dir, nil)
+	}
+
+	return NewReplayer(dir)
+}
@@ -0,0 +1,98 @@
+package gogtrendstest_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/groovili/gogtrends"
+	"github.com/groovili/gogtrends/gogtrendstest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	dailyPayload   = `)]}',{"default":{"trendingSearchesDays":[{"trendingSearches":[{"title":{"query":"Golang"}}]}]}}`
+	explorePayload = `)]}'{"widgets":[{"token":"token-1","id":"TIMESERIES","request":{"time":"today 12-m",` +
+		`"comparisonItem":[{"geo":{},"complexKeywordsRestriction":{"keyword":[{"type":"BROAD","value":"Golang"}]}}]}}]}`
+	multilinePayload = `)]}',{"default":{"timelineData":[{"time":"1600000000","value":[42]}]}}`
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case gogtrends.EndpointDaily:
+			http.SetCookie(w, &http.Cookie{Name: "NID", Value: "secret-session"})
+			_, _ = w.Write([]byte(dailyPayload))
+		case gogtrends.EndpointExplore:
+			_, _ = w.Write([]byte(explorePayload))
+		case gogtrends.EndpointInterestOverTime:
+			assert.Equal(t, "token-1", r.URL.Query().Get("token"))
+			_, _ = w.Write([]byte(multilinePayload))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	dir, err := ioutil.TempDir("", "gogtrendstest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	run := func(c *gogtrends.Client) {
+		ctx := context.Background()
+
+		daily, err := c.Daily(ctx, "EN", "US")
+		assert.NoError(t, err)
+		assert.Equal(t, "Golang", daily[0].Title.Query)
+
+		explore, err := c.Explore(ctx, &gogtrends.ExploreRequest{
+			ComparisonItems: []*gogtrends.ComparisonItem{{Keyword: "Golang", Time: "today 12-m"}},
+		}, "EN")
+		assert.NoError(t, err)
+		assert.Len(t, explore, 1)
+
+		overTime, err := c.InterestOverTime(ctx, explore[0], "EN")
+		assert.NoError(t, err)
+		assert.Equal(t, []int{42}, overTime[0].Value)
+	}
+
+	run(gogtrends.New(
+		gogtrends.WithBaseURL(srv.URL),
+		gogtrends.WithHTTPClient(&http.Client{Transport: gogtrendstest.NewRecorder(dir, nil)}),
+	))
+
+	// replay works without server
+	srv.Close()
+
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+
+	// session cookies aren't recorded
+	for _, v := range files {
+		b, err := ioutil.ReadFile(filepath.Join(dir, v.Name()))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(b), "secret-session")
+	}
+
+	c := gogtrends.New(
+		gogtrends.WithBaseURL(srv.URL),
+		gogtrends.WithHTTPClient(&http.Client{Transport: gogtrendstest.NewReplayer(dir)}),
+		gogtrends.WithRetryPolicy(gogtrends.RetryPolicy{MaxAttempts: 1}),
+	)
+	run(c)
+
+	_, err = c.Daily(context.Background(), "EN", "GB")
+	assert.True(t, errors.Is(err, gogtrendstest.ErrFixtureNotFound))
+}
+
+func TestNormalizeURL(t *testing.T) {
+	r1 := httptest.NewRequest(http.MethodGet, "https://trends.google.com/trends/api/explore?hl=EN&tz=0", nil)
+	r2 := httptest.NewRequest(http.MethodGet, "http://trends.google.com/trends/api/explore?tz=0&hl=EN", nil)
+
+	assert.Equal(t, gogtrendstest.NormalizeURL(r1), gogtrendstest.NormalizeURL(r2))
+}
@@ -0,0 +1,671 @@
+package gogtrendstest
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Paths served by fake server, same as Google Trends API paths relative to base url.
+const (
+	PathDaily        = "/dailytrends"
+	PathRealtime     = "/realtimetrends"
+	PathExplore      = "/explore"
+	PathCategories   = "/explore/pickers/category"
+	PathLocations    = "/explore/pickers/geo"
+	PathMultiline    = "/widgetdata/multiline"
+	PathComparedGeo  = "/widgetdata/comparedgeo"
+	PathRelated      = "/widgetdata/relatedsearches"
+	PathAutocomplete = "/autocomplete"
+	PathStories      = "/stories"
+	PathStorySummary = "/stories/summary"
+	// PathWarmUp is a page which sets session cookie, analogue of Google Trends explore page
+	PathWarmUp = "/warmup"
+
+	// SessionCookie is a name of cookie issued by fake server
+	SessionCookie = "NID"
+
+	// prefix is an anti-XSSI prefix of Google Trends responses
+	prefix      = ")]}'"
+	dateLayout  = "20060102"
+	maxKeywords = 5
+
+	// realtime board has realtimeStories, first page includes realtimePage of them
+	realtimeStories = 50
+	realtimePage    = 10
+)
+
+// Fault is a scripted response of fake server, which replaces normal one.
+type Fault struct {
+	// Status is a response code, 200 if not set
+	Status int
+	// Header is added to response, like Retry-After
+	Header http.Header
+	// Cookies are set with response
+	Cookies []*http.Cookie
+	// Body replaces response payload if set
+	Body string
+	// Delay is a time before response, request context cancellation stops waiting
+	Delay time.Duration
+}
+
+// RateLimited is 429 response with new session cookie, like Google Trends answers to requests without session.
+func RateLimited() Fault {
+	return Fault{
+		Status:  http.StatusTooManyRequests,
+		Cookies: []*http.Cookie{sessionCookie()},
+	}
+}
+
+// RetryAfter is 429 response without cookies, which asks to wait before next request.
+func RetryAfter(d time.Duration) Fault {
+	h := make(http.Header)
+	h.Set("Retry-After", strconv.Itoa(int(d.Seconds())))
+
+	return Fault{Status: http.StatusTooManyRequests, Header: h}
+}
+
+// ServerError is a response with status code from 5xx range.
+func ServerError(status int) Fault {
+	return Fault{Status: status}
+}
+
+// Malformed is a successful response with invalid JSON payload.
+func Malformed() Fault {
+	return Fault{Body: prefix + `{"default":{`}
+}
+
+// HTMLPage is a successful response with HTML instead of JSON, like consent or captcha page.
+func HTMLPage() Fault {
+	return Fault{Body: `<!DOCTYPE html><html><head><title>Before you continue</title></head><body></body></html>`}
+}
+
+// Slow is a successful response delayed by d.
+func Slow(d time.Duration) Fault {
+	return Fault{Delay: d}
+}
+
+func sessionCookie() *http.Cookie {
+	return &http.Cookie{Name: SessionCookie, Value: "fake-session", Path: "/", MaxAge: 3600}
+}
+
+// Server is an in-process fake of Google Trends API for integration tests. Explore issues tokens for widgets,
+// which are validated by widget endpoints, any response can be replaced with scripted faults.
+// Use URL as client base url and WarmUpURL as client warm up url.
+type Server struct {
+	*httptest.Server
+
+	// RequireSession makes server answer like RateLimited to requests without session cookie
+	RequireSession bool
+
+	mu       sync.Mutex
+	today    time.Time
+	faults   map[string][]Fault
+	requests map[string]int
+	tokens   map[string]string
+	counter  int
+}
+
+// NewServer starts fake server, it should be closed after test.
+func NewServer() *Server {
+	s := &Server{
+		today:    time.Now().UTC(),
+		faults:   make(map[string][]Fault),
+		requests: make(map[string]int),
+		tokens:   make(map[string]string),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(PathDaily, s.daily)
+	mux.HandleFunc(PathRealtime, s.realtime)
+	mux.HandleFunc(PathExplore, s.explore)
+	mux.HandleFunc(PathCategories, s.categories)
+	mux.HandleFunc(PathLocations, s.locations)
+	mux.HandleFunc(PathMultiline, s.multiline)
+	mux.HandleFunc(PathComparedGeo, s.comparedGeo)
+	mux.HandleFunc(PathRelated, s.related)
+	mux.HandleFunc(PathAutocomplete+"/", s.autocomplete)
+	mux.HandleFunc(PathStories+"/", s.story)
+	mux.HandleFunc(PathStorySummary, s.storySummary)
+	mux.HandleFunc(PathWarmUp, s.warmUp)
+
+	s.Server = httptest.NewServer(s.handle(mux))
+
+	return s
+}
+
+// WarmUpURL is an url of page which sets session cookie.
+func (s *Server) WarmUpURL() string {
+	return s.URL + PathWarmUp
+}
+
+// Script queues faults for next requests to path, one fault per request. Normal responses continue after them.
+func (s *Server) Script(path string, faults ...Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults[path] = append(s.faults[path], faults...)
+}
+
+// Today returns date of the most recent daily and realtime trends, current date by default.
+func (s *Server) Today() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.today
+}
+
+// SetToday changes date of the most recent daily and realtime trends, it is safe to call while serving.
+func (s *Server) SetToday(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.today = t
+}
+
+// Requests returns number of requests received by path, including faulted ones.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.requests[path]
+}
+
+// Reset removes scripted faults, issued tokens and requests counters.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = make(map[string][]Fault)
+	s.requests = make(map[string]int)
+	s.tokens = make(map[string]string)
+}
+
+// route is a path used for scripting and counters, paths with keyword or id are counted without it.
+func route(p string) string {
+	if p == PathStorySummary {
+		return p
+	}
+
+	for _, v := range []string{PathAutocomplete, PathStories} {
+		if strings.HasPrefix(p, v+"/") {
+			return v
+		}
+	}
+
+	return p
+}
+
+func (s *Server) handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := route(r.URL.Path)
+
+		s.mu.Lock()
+		s.requests[path]++
+		var fault *Fault
+		if q := s.faults[path]; len(q) > 0 {
+			fault, s.faults[path] = &q[0], q[1:]
+		}
+		s.mu.Unlock()
+
+		if fault == nil && s.RequireSession && path != PathWarmUp {
+			if _, err := r.Cookie(SessionCookie); err != nil {
+				f := RateLimited()
+				fault = &f
+			}
+		}
+
+		if fault == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if fault.Delay > 0 {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(fault.Delay):
+			}
+		}
+
+		for k, v := range fault.Header {
+			w.Header()[k] = v
+		}
+
+		for _, v := range fault.Cookies {
+			http.SetCookie(w, v)
+		}
+
+		if len(fault.Body) == 0 && (fault.Status == 0 || fault.Status == http.StatusOK) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if fault.Status != 0 {
+			w.WriteHeader(fault.Status)
+		}
+		_, _ = w.Write([]byte(fault.Body))
+	})
+}
+
+func (s *Server) write(w http.ResponseWriter, xssi string, v interface{}) {
+	b, err := jsoniter.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_, _ = w.Write([]byte(xssi))
+	_, _ = w.Write(b)
+}
+
+func (s *Server) warmUp(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, sessionCookie())
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write([]byte(`<!DOCTYPE html><html><body>Google Trends</body></html>`))
+}
+
+func (s *Server) daily(w http.ResponseWriter, r *http.Request) {
+	end := s.Today()
+	if ed := r.URL.Query().Get("ed"); len(ed) > 0 {
+		t, err := time.Parse(dateLayout, ed)
+		if err != nil {
+			http.Error(w, "invalid ed", http.StatusBadRequest)
+			return
+		}
+		end = t
+	}
+
+	geo := r.URL.Query().Get("geo")
+
+	// google returns requested day and the day before
+	days := make([]interface{}, 0, 2)
+	for i := 0; i < 2; i++ {
+		d := end.AddDate(0, 0, -i)
+		days = append(days, map[string]interface{}{
+			"date":          d.Format(dateLayout),
+			"formattedDate": d.Format("Monday, January 2, 2006"),
+			"trendingSearches": []interface{}{
+				trendingSearch(fmt.Sprintf("%s trend %s", geo, d.Format(dateLayout)), "200K+"),
+				trendingSearch(fmt.Sprintf("%s news %s", geo, d.Format(dateLayout)), "50K+"),
+			},
+		})
+	}
+
+	s.write(w, prefix+",", map[string]interface{}{
+		"default": map[string]interface{}{
+			"trendingSearchesDays":  days,
+			"endDateForNextRequest": end.AddDate(0, 0, -2).Format(dateLayout),
+			"rssFeedPageUrl":        "https://trends.google.com/trends/trendingsearches/daily/rss?geo=" + geo,
+		},
+	})
+}
+
+func trendingSearch(query, traffic string) map[string]interface{} {
+	link := "/trends/explore?q=" + url.QueryEscape(query) + "&date=now+7-d"
+
+	return map[string]interface{}{
+		"title":            map[string]interface{}{"query": query, "exploreLink": link},
+		"formattedTraffic": traffic,
+		"relatedQueries": []interface{}{
+			map[string]interface{}{"query": query + " live", "exploreLink": link + "+live"},
+		},
+		"image": map[string]interface{}{
+			"newsUrl":  "https://example.com/news",
+			"source":   "Example News",
+			"imageUrl": "https://example.com/image.jpg",
+		},
+		"articles": []interface{}{
+			map[string]interface{}{
+				"title":   query + " article",
+				"timeAgo": "3h ago",
+				"source":  "Example News",
+				"url":     "https://example.com/article",
+				"snippet": "Snippet about " + query,
+				"image": map[string]interface{}{
+					"newsUrl":  "https://example.com/article",
+					"source":   "Example News",
+					"imageUrl": "https://example.com/article.jpg",
+				},
+			},
+		},
+		"shareUrl": "https://trends.google.com/trends/trendingsearches/daily?geo=US#" + url.QueryEscape(query),
+	}
+}
+
+func (s *Server) realtime(w http.ResponseWriter, r *http.Request) {
+	geo := r.URL.Query().Get("geo")
+	if len(geo) == 0 {
+		http.Error(w, "geo is required", http.StatusBadRequest)
+		return
+	}
+
+	stories := make([]interface{}, 0)
+	for i := 0; i < realtimePage; i++ {
+		stories = append(stories, trendingStory(storyID(geo, i)))
+	}
+
+	ids := make([]string, 0)
+	for i := 0; i < realtimeStories; i++ {
+		ids = append(ids, storyID(geo, i))
+	}
+
+	s.write(w, prefix, map[string]interface{}{
+		"featuredStoryIds": []string{},
+		"trendingStoryIds": ids,
+		"storySummaries": map[string]interface{}{
+			"featuredStories": []interface{}{},
+			"trendingStories": stories,
+		},
+		"date":          s.Today().Format(dateLayout),
+		"hideAllImages": false,
+	})
+}
+
+func storyID(geo string, i int) string {
+	return fmt.Sprintf("%s_lnk_story%d_en", geo, i)
+}
+
+func trendingStory(id string) map[string]interface{} {
+	title := "Story " + id
+	return map[string]interface{}{
+		"id":          id,
+		"title":       title,
+		"entityNames": []string{"Entity " + id, "Topic " + id},
+		"image": map[string]interface{}{
+			"newsUrl":  "https://example.com/" + id,
+			"source":   "Example News",
+			"imgUrl":   "https://example.com/" + id + ".jpg",
+			"imageUrl": "https://example.com/" + id + ".jpg",
+		},
+		"articles": []interface{}{
+			map[string]interface{}{
+				"articleTitle": title + " article",
+				"url":          "https://example.com/" + id + "/article",
+				"source":       "Example News",
+				"time":         "1 hour ago",
+				"snippet":      "Snippet of " + title,
+			},
+		},
+		"shareUrl": "https://trends.google.com/stories/" + id,
+	}
+}
+
+func (s *Server) categories(w http.ResponseWriter, r *http.Request) {
+	s.write(w, prefix, map[string]interface{}{
+		"name": "All categories",
+		"id":   0,
+		"children": []interface{}{
+			map[string]interface{}{
+				"name": "Computers & Electronics",
+				"id":   5,
+				"children": []interface{}{
+					map[string]interface{}{"name": "Programming", "id": 31},
+				},
+			},
+			map[string]interface{}{"name": "News", "id": 16},
+		},
+	})
+}
+
+func (s *Server) locations(w http.ResponseWriter, r *http.Request) {
+	s.write(w, prefix, map[string]interface{}{
+		"name": "",
+		"id":   "",
+		"children": []interface{}{
+			map[string]interface{}{
+				"name": "United States",
+				"id":   "US",
+				"children": []interface{}{
+					map[string]interface{}{
+						"name": "New York",
+						"id":   "US-NY",
+						"children": []interface{}{
+							map[string]interface{}{"name": "New York NY", "id": "501"},
+						},
+					},
+				},
+			},
+			map[string]interface{}{"name": "United Kingdom", "id": "GB"},
+			map[string]interface{}{"name": "Germany", "id": "DE"},
+		},
+	})
+}
+
+type exploreItem struct {
+	Keyword string `json:"keyword"`
+	Geo     string `json:"geo"`
+	Time    string `json:"time"`
+}
+
+type exploreRequest struct {
+	ComparisonItems []*exploreItem `json:"comparisonItem"`
+	Category        int            `json:"category"`
+}
+
+func (s *Server) explore(w http.ResponseWriter, r *http.Request) {
+	req := new(exploreRequest)
+	if err := jsoniter.UnmarshalFromString(r.URL.Query().Get("req"), req); err != nil {
+		http.Error(w, "invalid req", http.StatusBadRequest)
+		return
+	}
+
+	if len(req.ComparisonItems) == 0 || len(req.ComparisonItems) > maxKeywords {
+		http.Error(w, "invalid comparison items", http.StatusBadRequest)
+		return
+	}
+
+	widgets := []interface{}{
+		s.widget("TIMESERIES", "Interest over time", req.ComparisonItems),
+		s.widget("GEO_MAP", "Interest by region", req.ComparisonItems),
+	}
+
+	if len(req.ComparisonItems) == 1 {
+		widgets = append(widgets,
+			s.widget("RELATED_TOPICS", "Related topics", req.ComparisonItems),
+			s.widget("RELATED_QUERIES", "Related queries", req.ComparisonItems),
+		)
+	} else {
+		for i, v := range req.ComparisonItems {
+			items := []*exploreItem{v}
+			widgets = append(widgets,
+				s.widget(fmt.Sprintf("GEO_MAP_%d", i), "Interest by region", items),
+				s.widget(fmt.Sprintf("RELATED_TOPICS_%d", i), "Related topics", items),
+				s.widget(fmt.Sprintf("RELATED_QUERIES_%d", i), "Related queries", items),
+			)
+		}
+	}
+
+	s.write(w, prefix, map[string]interface{}{"widgets": widgets})
+}
+
+// widget issues token for widget id.
+func (s *Server) widget(id, title string, items []*exploreItem) map[string]interface{} {
+	s.mu.Lock()
+	s.counter++
+	token := fmt.Sprintf("APP6_fake_%d", s.counter)
+	s.tokens[token] = id
+	s.mu.Unlock()
+
+	compItems := make([]interface{}, 0, len(items))
+	for _, v := range items {
+		geo := map[string]string{}
+		if len(v.Geo) > 0 {
+			geo["country"] = v.Geo
+		}
+
+		compItems = append(compItems, map[string]interface{}{
+			"geo":  geo,
+			"time": v.Time,
+			"complexKeywordsRestriction": map[string]interface{}{
+				"keyword": []interface{}{map[string]string{"type": "BROAD", "value": v.Keyword}},
+			},
+		})
+	}
+
+	req := map[string]interface{}{
+		"comparisonItem": compItems,
+		"requestOptions": map[string]interface{}{"property": "", "backend": "IZG", "category": 0},
+		"locale":         "en-US",
+	}
+
+	if strings.HasPrefix(id, "RELATED_") {
+		req = map[string]interface{}{
+			"restriction":    compItems[0],
+			"keywordType":    "ENTITY",
+			"metric":         []string{"TOP", "RISING"},
+			"requestOptions": map[string]interface{}{"property": "", "backend": "IZG", "category": 0},
+			"language":       "en",
+		}
+		if strings.HasPrefix(id, "RELATED_QUERIES") {
+			req["keywordType"] = "QUERY"
+		}
+	}
+
+	return map[string]interface{}{
+		"token":   token,
+		"type":    "fe_" + strings.ToLower(id),
+		"title":   title,
+		"id":      id,
+		"request": req,
+	}
+}
+
+// validToken checks that token was issued for widget with prefix.
+func (s *Server) validToken(w http.ResponseWriter, r *http.Request, prefixes ...string) bool {
+	s.mu.Lock()
+	id, ok := s.tokens[r.URL.Query().Get("token")]
+	s.mu.Unlock()
+
+	if ok {
+		for _, p := range prefixes {
+			if strings.HasPrefix(id, p) {
+				return true
+			}
+		}
+	}
+
+	http.Error(w, "invalid token", http.StatusUnauthorized)
+
+	return false
+}
+
+func (s *Server) multiline(w http.ResponseWriter, r *http.Request) {
+	if !s.validToken(w, r, "TIMESERIES") {
+		return
+	}
+
+	start := s.Today().AddDate(0, 0, -7)
+	timeline := make([]interface{}, 0, 7)
+	for i := 0; i < 7; i++ {
+		t := start.AddDate(0, 0, i)
+		timeline = append(timeline, map[string]interface{}{
+			"time":              strconv.FormatInt(t.Unix(), 10),
+			"formattedTime":     t.Format("Jan 2, 2006"),
+			"formattedAxisTime": t.Format("Jan 2"),
+			"value":             []int{40 + i*10},
+			"hasData":           []bool{true},
+			"formattedValue":    []string{strconv.Itoa(40 + i*10)},
+		})
+	}
+
+	s.write(w, prefix+",", map[string]interface{}{
+		"default": map[string]interface{}{"timelineData": timeline},
+	})
+}
+
+func (s *Server) comparedGeo(w http.ResponseWriter, r *http.Request) {
+	if !s.validToken(w, r, "GEO_MAP") {
+		return
+	}
+
+	s.write(w, prefix+",", map[string]interface{}{
+		"default": map[string]interface{}{
+			"geoMapData": []interface{}{
+				map[string]interface{}{
+					"geoCode": "US", "geoName": "United States", "value": []int{100},
+					"formattedValue": []string{"100"}, "maxValueIndex": 0, "hasData": []bool{true},
+				},
+				map[string]interface{}{
+					"geoCode": "GB", "geoName": "United Kingdom", "value": []int{64},
+					"formattedValue": []string{"64"}, "maxValueIndex": 0, "hasData": []bool{true},
+				},
+			},
+		},
+	})
+}
+
+func (s *Server) related(w http.ResponseWriter, r *http.Request) {
+	if !s.validToken(w, r, "RELATED_QUERIES", "RELATED_TOPICS") {
+		return
+	}
+
+	keyword := func(q string, v int) map[string]interface{} {
+		return map[string]interface{}{
+			"query": q, "value": v, "formattedValue": strconv.Itoa(v), "hasData": true,
+			"link": "/trends/explore?q=" + url.QueryEscape(q),
+		}
+	}
+
+	s.write(w, prefix+",", map[string]interface{}{
+		"default": map[string]interface{}{
+			"rankedList": []interface{}{
+				map[string]interface{}{"rankedKeyword": []interface{}{keyword("golang tutorial", 100), keyword("go vs rust", 55)}},
+				map[string]interface{}{"rankedKeyword": []interface{}{keyword("go generics", 250)}},
+			},
+		},
+	})
+}
+
+func (s *Server) autocomplete(w http.ResponseWriter, r *http.Request) {
+	word := strings.TrimPrefix(r.URL.Path, PathAutocomplete+"/")
+
+	s.write(w, prefix+",", map[string]interface{}{
+		"default": map[string]interface{}{
+			"topics": []interface{}{
+				map[string]string{"mid": "/m/09gbxjr", "title": word, "type": "Programming language"},
+				map[string]string{"mid": "/m/0fake", "title": word + " game", "type": "Topic"},
+			},
+		},
+	})
+}
+
+func (s *Server) story(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, PathStories+"/")
+	items := []*exploreItem{{Keyword: "Entity " + id, Time: "now 1-d"}}
+
+	articles := trendingStory(id)["articles"].([]interface{})
+	articles = append(articles, map[string]interface{}{
+		"articleTitle": "Story " + id + " analysis",
+		"url":          "https://example.com/" + id + "/analysis",
+		"source":       "Example Times",
+		"time":         "2 hours ago",
+		"snippet":      "Analysis of story " + id,
+	})
+
+	s.write(w, prefix, map[string]interface{}{
+		"title": "Story " + id,
+		"widgets": []interface{}{
+			s.widget("TIMESERIES", "Interest over time", items),
+			map[string]interface{}{"id": "NEWS_ARTICLES", "type": "fe_news", "title": "Top news", "articles": articles},
+			s.widget("RELATED_QUERIES", "Related queries", items),
+		},
+	})
+}
+
+func (s *Server) storySummary(w http.ResponseWriter, r *http.Request) {
+	stories := make([]interface{}, 0)
+	for _, id := range r.URL.Query()["id"] {
+		stories = append(stories, trendingStory(id))
+	}
+
+	s.write(w, prefix, map[string]interface{}{"trendingStories": stories})
+}
@@ -0,0 +1,166 @@
+package gogtrendstest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/groovili/gogtrends"
+	"github.com/groovili/gogtrends/gogtrendstest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newClient(s *gogtrendstest.Server, attempts int) *gogtrends.Client {
+	return gogtrends.New(
+		gogtrends.WithBaseURL(s.URL),
+		gogtrends.WithWarmUpURL(s.WarmUpURL()),
+		gogtrends.WithRetryPolicy(gogtrends.RetryPolicy{
+			MaxAttempts:       attempts,
+			MinBackoff:        time.Millisecond,
+			MaxBackoff:        10 * time.Millisecond,
+			RetryableStatuses: []int{http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway},
+		}),
+	)
+}
+
+func TestServerEndpoints(t *testing.T) {
+	s := gogtrendstest.NewServer()
+	defer s.Close()
+
+	c := newClient(s, 1)
+	ctx := context.Background()
+
+	daily, err := c.Daily(ctx, "EN", "US")
+	assert.NoError(t, err)
+	assert.Len(t, daily, 4)
+
+	realtime, err := c.Realtime(ctx, "EN", "US", "all")
+	assert.NoError(t, err)
+	assert.Len(t, realtime, 10)
+	assert.NotEmpty(t, realtime[0].Articles)
+
+	cats, err := c.ExploreCategories(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cats.Children)
+
+	locs, err := c.ExploreLocations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "US", locs.Children[0].ID)
+
+	search, err := c.Search(ctx, "Go", "EN")
+	assert.NoError(t, err)
+	assert.Equal(t, "Go", search[0].Title)
+
+	widgets, err := c.Explore(ctx, &gogtrends.ExploreRequest{
+		ComparisonItems: []*gogtrends.ComparisonItem{{Keyword: "Go", Geo: "US", Time: "today 12-m"}},
+	}, "EN")
+	assert.NoError(t, err)
+	assert.Len(t, widgets, 4)
+
+	overTime, err := c.InterestOverTime(ctx, widgets[0], "EN")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, overTime)
+
+	byLoc, err := c.InterestByLocation(ctx, widgets[1], "EN")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, byLoc)
+
+	related, err := c.Related(ctx, widgets[3], "EN")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, related)
+
+	compare, err := c.Explore(ctx, &gogtrends.ExploreRequest{
+		ComparisonItems: []*gogtrends.ComparisonItem{
+			{Keyword: "Go", Geo: "US", Time: "today 12-m"},
+			{Keyword: "Rust", Geo: "US", Time: "today 12-m"},
+		},
+	}, "EN")
+	assert.NoError(t, err)
+	assert.Len(t, compare, 8)
+}
+
+func TestServerTokens(t *testing.T) {
+	s := gogtrendstest.NewServer()
+	defer s.Close()
+
+	c := newClient(s, 1)
+	ctx := context.Background()
+
+	widgets, err := c.Explore(ctx, &gogtrends.ExploreRequest{
+		ComparisonItems: []*gogtrends.ComparisonItem{{Keyword: "Go", Time: "today 12-m"}},
+	}, "EN")
+	assert.NoError(t, err)
+
+	// token issued for timeseries widget is not valid for another one
+	w := *widgets[1]
+	w.Token = widgets[0].Token
+	_, err = c.InterestByLocation(ctx, &w, "EN")
+
+	var httpErr *gogtrends.HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+
+	// tokens are forgotten on reset
+	s.Reset()
+	_, err = c.InterestOverTime(ctx, widgets[0], "EN")
+	assert.Error(t, err)
+}
+
+func TestServerFaults(t *testing.T) {
+	s := gogtrendstest.NewServer()
+	defer s.Close()
+
+	c := newClient(s, 3)
+	ctx := context.Background()
+
+	// rate limit with new cookies is retried with them
+	s.Script(gogtrendstest.PathDaily, gogtrendstest.RateLimited())
+	_, err := c.Daily(ctx, "EN", "US")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, s.Requests(gogtrendstest.PathDaily))
+
+	// server errors are retried until attempts end
+	s.Script(gogtrendstest.PathRealtime,
+		gogtrendstest.ServerError(http.StatusBadGateway),
+		gogtrendstest.ServerError(http.StatusInternalServerError),
+		gogtrendstest.ServerError(http.StatusInternalServerError),
+	)
+	_, err = c.Realtime(ctx, "EN", "US", "all")
+	assert.True(t, gogtrends.IsServerError(err))
+	assert.Equal(t, 3, s.Requests(gogtrendstest.PathRealtime))
+
+	// Retry-After longer than max backoff is not retried
+	s.Script(gogtrendstest.PathAutocomplete, gogtrendstest.RetryAfter(time.Minute))
+	_, err = c.Search(ctx, "Go", "EN")
+	assert.True(t, gogtrends.IsRateLimited(err))
+	assert.Equal(t, 1, s.Requests(gogtrendstest.PathAutocomplete))
+
+	s.Script(gogtrendstest.PathCategories, gogtrendstest.Malformed())
+	_, err = c.ExploreCategories(ctx)
+	assert.Error(t, err)
+
+	s.Script(gogtrendstest.PathLocations, gogtrendstest.Slow(time.Second))
+	tctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = c.ExploreLocations(tctx)
+	assert.Error(t, err)
+}
+
+func TestServerSession(t *testing.T) {
+	s := gogtrendstest.NewServer()
+	defer s.Close()
+	s.RequireSession = true
+
+	c := newClient(s, 1)
+	ctx := context.Background()
+
+	_, err := c.Daily(ctx, "EN", "US")
+	assert.True(t, gogtrends.IsRateLimited(err))
+
+	assert.NoError(t, c.WarmUp(ctx))
+	_, err = c.Daily(ctx, "EN", "US")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, s.Requests(gogtrendstest.PathDaily))
+}
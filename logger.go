@@ -0,0 +1,154 @@
+package gogtrends
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Logger is a leveled logger with key-value pairs after message, like `log/slog`.
+// *slog.Logger satisfies it without adapters.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+	Info(msg string, args ...interface{})
+	Warn(msg string, args ...interface{})
+	Error(msg string, args ...interface{})
+}
+
+// Level is a minimal level of messages written by StdLogger.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+var levelNames = map[Level]string{
+	LevelDebug: "DEBUG",
+	LevelInfo:  "INFO",
+	LevelWarn:  "WARN",
+	LevelError: "ERROR",
+}
+
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("LEVEL(%d)", int(l))
+}
+
+// StdLogger writes key-value messages with level to standard library logger.
+type StdLogger struct {
+	l     *log.Logger
+	level Level
+}
+
+// NewStdLogger creates logger for messages starting from level, if l is nil standard logger of `log` package is used.
+func NewStdLogger(l *log.Logger, level Level) *StdLogger {
+	return &StdLogger{l: l, level: level}
+}
+
+// Debug writes message with LevelDebug.
+func (s *StdLogger) Debug(msg string, args ...interface{}) {
+	s.write(LevelDebug, msg, args)
+}
+
+// Info writes message with LevelInfo.
+func (s *StdLogger) Info(msg string, args ...interface{}) {
+	s.write(LevelInfo, msg, args)
+}
+
+// Warn writes message with LevelWarn.
+func (s *StdLogger) Warn(msg string, args ...interface{}) {
+	s.write(LevelWarn, msg, args)
+}
+
+// Error writes message with LevelError.
+func (s *StdLogger) Error(msg string, args ...interface{}) {
+	s.write(LevelError, msg, args)
+}
+
+func (s *StdLogger) write(level Level, msg string, args []interface{}) {
+	if level < s.level {
+		return
+	}
+
+	b := new(strings.Builder)
+	fmt.Fprintf(b, "level=%s msg=%q", level, msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			fmt.Fprintf(b, " !BADKEY=%v", args[i])
+			break
+		}
+		fmt.Fprintf(b, " %v=%v", args[i], args[i+1])
+	}
+
+	if s.l == nil {
+		log.Println(b.String())
+		return
+	}
+	s.l.Println(b.String())
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+func (nopLogger) Info(string, ...interface{})  {}
+func (nopLogger) Warn(string, ...interface{})  {}
+func (nopLogger) Error(string, ...interface{}) {}
+
+// loggerBox keeps atomic.Value type consistent for different loggers.
+type loggerBox struct {
+	Logger
+}
+
+func (c *Client) log() Logger {
+	return c.logger.Load().(loggerBox).Logger
+}
+
+func (c *Client) setLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
+	c.logger.Store(loggerBox{l})
+}
+
+// logRequest writes request summary, tokens and cookies are redacted unless it's disabled.
+func (c *Client) logRequest(endpoint string, u *url.URL, rt *roundTrip, latency time.Duration, size int, err error) {
+	params := u.RawQuery
+	if c.redact {
+		params = sanitizeQuery(u.Query())
+	}
+
+	cookies := make([]string, 0, len(rt.cookies))
+	for _, v := range rt.cookies {
+		val := v.Value
+		if c.redact {
+			val = redacted
+		}
+		cookies = append(cookies, (&http.Cookie{Name: v.Name, Value: val}).String())
+	}
+
+	args := []interface{}{
+		"endpoint", endpoint,
+		"params", params,
+		"status", rt.status,
+		"latency", latency,
+		"bytes", size,
+		"retries", rt.attempts - 1,
+		"cookies", strings.Join(cookies, "; "),
+	}
+
+	if err != nil {
+		c.log().Warn("request failed", append(args, "error", err)...)
+		return
+	}
+
+	c.log().Debug("request", args...)
+}
@@ -0,0 +1,22 @@
+package gogtrends
+
+import "time"
+
+// Metrics receives statistics of client requests, `prometheus` sub-package has implementation with collectors.
+// Methods are called concurrently.
+type Metrics interface {
+	// RequestDone is called once for every request after all attempts. Status is 0 if no response was received,
+	// latency includes retries, waiting for rate limiter
+	// and reading of streamed response, size is a length of response payload.
+	RequestDone(op Operation, status int, failed bool, latency time.Duration, size int)
+	// Retried is called for every repeated attempt with status of the previous one, 0 for transport errors.
+	Retried(op Operation, status int)
+	// CookiesRefreshed is called when Google answers with new session cookies to repeat request with.
+	CookiesRefreshed(op Operation)
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) RequestDone(Operation, int, bool, time.Duration, int) {}
+func (nopMetrics) Retried(Operation, int)                               {}
+func (nopMetrics) CookiesRefreshed(Operation)                           {}
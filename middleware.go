@@ -0,0 +1,106 @@
+package gogtrends
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Operation is a name of client method which performs API request.
+type Operation string
+
+const (
+	OpDaily              Operation = "Daily"
+	OpRealtime           Operation = "Realtime"
+	OpExploreCategories  Operation = "ExploreCategories"
+	OpExploreLocations   Operation = "ExploreLocations"
+	OpExplore            Operation = "Explore"
+	OpInterestOverTime   Operation = "InterestOverTime"
+	OpInterestByLocation Operation = "InterestByLocation"
+	OpRelated            Operation = "Related"
+	OpSearch             Operation = "Search"
+	OpStoryDetails       Operation = "StoryDetails"
+	OpStorySummary       Operation = "StorySummary"
+)
+
+// Request is a logical API request passed through middleware chain.
+// Middleware can change it before calling next handler.
+type Request struct {
+	Operation Operation
+	// Endpoint is API path relative to base url, like EndpointExplore
+	Endpoint string
+	// URL is a request url without query
+	URL *url.URL
+	// Params are query params, JSON request is in `req` param
+	Params url.Values
+	// Header is sent in addition to client headers
+	Header http.Header
+	// Explore is an input of Explore operation, nil for others
+	Explore *ExploreRequest
+	// Widget is an input of InterestOverTime, InterestByLocation and Related operations, nil for others
+	Widget *ExploreWidget
+
+	// noCache skips client response cache
+	noCache bool
+	// stream allows transport to return not read body, when nothing needs raw payload
+	stream bool
+}
+
+// Response is a successful API response.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	// Payload is a raw response body, it's not valid JSON until anti-XSSI prefix is removed
+	Payload []byte
+
+	// commit stores response in client cache, it's called after payload is decoded
+	commit func()
+	// body is a not read response body, it's set instead of Payload for streamed responses
+	body io.ReadCloser
+}
+
+// Decode strips anti-XSSI prefix and decodes JSON payload into dest, it can be used by middlewares
+// to inspect response. Payload of HTML page returns ErrUnexpectedPayload.
+func (r *Response) Decode(dest interface{}) error {
+	if r.body != nil {
+		defer r.close()
+		return decode(r.body, dest)
+	}
+
+	return decode(bytes.NewReader(r.Payload), dest)
+}
+
+// buffer reads streamed body into Payload, so response can be shared or decoded few times.
+func (r *Response) buffer() error {
+	if r.body == nil {
+		return nil
+	}
+	defer r.close()
+
+	b, err := ioutil.ReadAll(r.body)
+	if err != nil {
+		return errors.Wrap(err, errDoRequest)
+	}
+	r.Payload = b
+
+	return nil
+}
+
+// close releases streamed body, it's safe to call for any response.
+func (r *Response) close() {
+	if r.body != nil {
+		r.body.Close()
+		r.body = nil
+	}
+}
+
+// Handler performs API request.
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware wraps Handler to add behaviour around requests, like headers, tracing or counters.
+type Middleware func(next Handler) Handler
@@ -0,0 +1,199 @@
+package gogtrends
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// defaultMultiConcurrency is a number of parallel requests of multi-geo methods by default.
+const defaultMultiConcurrency = 4
+
+// MultiOptions configures DailyMulti and RealtimeMulti, nil is default options.
+type MultiOptions struct {
+	// Concurrency is a max number of parallel requests, 4 by default
+	Concurrency int
+	// Validate checks locations against ExploreLocations tree, unknown ones fail with ErrInvalidLocation
+	Validate bool
+	// AllCountries requests every country supported by Google Trends instead of provided locations
+	AllCountries bool
+}
+
+// MultiError is a set of errors of multi-geo request keyed by geo.
+type MultiError map[string]error
+
+func (e MultiError) Error() string {
+	geos := make([]string, 0, len(e))
+	for k := range e {
+		geos = append(geos, k)
+	}
+	sort.Strings(geos)
+
+	msgs := make([]string, 0, len(geos))
+	for _, v := range geos {
+		msgs = append(msgs, v+": "+e[v].Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// DailyMulti gets daily trends for every location with bounded concurrency.
+// Successful results are keyed by geo, failed locations are returned as MultiError.
+func (c *Client) DailyMulti(ctx context.Context, hl string, locs []string, opts *MultiOptions) (map[string][]*TrendingSearch, error) {
+	res, err := c.multi(ctx, locs, opts, func(ctx context.Context, loc string) (interface{}, error) {
+		return c.Daily(ctx, hl, loc)
+	})
+
+	out := make(map[string][]*TrendingSearch, len(res))
+	for k, v := range res {
+		out[k] = v.([]*TrendingSearch)
+	}
+
+	return out, err
+}
+
+// RealtimeMulti gets realtime trends for every location with bounded concurrency.
+// Successful results are keyed by geo, failed locations are returned as MultiError.
+func (c *Client) RealtimeMulti(ctx context.Context, hl string, locs []string, cat string, opts *MultiOptions) (map[string][]*TrendingStory, error) {
+	if !c.validateCategory(cat) {
+		return nil, ErrInvalidCategory
+	}
+
+	res, err := c.multi(ctx, locs, opts, func(ctx context.Context, loc string) (interface{}, error) {
+		return c.Realtime(ctx, hl, loc, cat)
+	})
+
+	out := make(map[string][]*TrendingStory, len(res))
+	for k, v := range res {
+		out[k] = v.([]*TrendingStory)
+	}
+
+	return out, err
+}
+
+// multi calls fn for every location with bounded concurrency.
+func (c *Client) multi(ctx context.Context, locs []string, opts *MultiOptions,
+	fn func(ctx context.Context, loc string) (interface{}, error)) (map[string]interface{}, error) {
+	if opts == nil {
+		opts = new(MultiOptions)
+	}
+
+	errs := make(MultiError)
+
+	if opts.Validate || opts.AllCountries {
+		tree, err := c.ExploreLocations(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if opts.AllCountries {
+			locs = make([]string, 0, len(tree.Children))
+			for _, v := range tree.Children {
+				locs = append(locs, v.ID)
+			}
+		}
+
+		valid := make([]string, 0, len(locs))
+		for _, v := range locs {
+			if tree.contains(v) {
+				valid = append(valid, v)
+			} else {
+				errs[v] = ErrInvalidLocation
+			}
+		}
+		locs = valid
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultMultiConcurrency
+	}
+
+	out := make(map[string]interface{}, len(locs))
+	mu := new(sync.Mutex)
+	wg := new(sync.WaitGroup)
+	sem := make(chan struct{}, concurrency)
+	seen := make(map[string]bool, len(locs))
+
+	for _, loc := range locs {
+		if seen[loc] {
+			continue
+		}
+		seen[loc] = true
+
+		select {
+		case <-ctx.Done():
+			mu.Lock()
+			errs[loc] = ctx.Err()
+			mu.Unlock()
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(loc string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res, err := fn(ctx, loc)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs[loc] = err
+				return
+			}
+			out[loc] = res
+		}(loc)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return out, errs
+	}
+
+	return out, nil
+}
+
+// contains checks if location tree has location with id. Metro areas have numeric ids in tree,
+// but they are requested with full code of their region, like "US-NY-501".
+func (t *ExploreLocTree) contains(id string) bool {
+	return len(id) > 0 && t.containsCode(id, "")
+}
+
+// containsCode checks location with id in subtree of location with parent code.
+func (t *ExploreLocTree) containsCode(id, parent string) bool {
+	if t == nil {
+		return false
+	}
+
+	code := t.ID
+	if len(parent) > 0 && isNumeric(code) {
+		code = parent + "-" + code
+	}
+
+	if strings.EqualFold(code, id) {
+		return true
+	}
+
+	for _, v := range t.Children {
+		if v.containsCode(id, code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isNumeric(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return len(s) > 0
+}
@@ -0,0 +1,79 @@
+package gogtrends
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/groovili/gogtrends/gogtrendstest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDailyMulti(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	ctx := context.Background()
+
+	out, err := c.DailyMulti(ctx, langEN, []string{"US", "GB", "XX", "US"}, &MultiOptions{Validate: true})
+	assert.Len(t, out, 2)
+	assert.Contains(t, out["GB"][0].Title.Query, "GB")
+
+	var multiErr MultiError
+	assert.True(t, errors.As(err, &multiErr))
+	assert.Len(t, multiErr, 1)
+	assert.Equal(t, ErrInvalidLocation, multiErr["XX"])
+	assert.Equal(t, "XX: invalid location param", err.Error())
+	assert.Equal(t, 2, srv.Requests(gogtrendstest.PathDaily))
+
+	out, err = c.DailyMulti(ctx, langEN, nil, &MultiOptions{AllCountries: true})
+	assert.NoError(t, err)
+	assert.Len(t, out, 3)
+
+	srv.Script(gogtrendstest.PathDaily, gogtrendstest.ServerError(http.StatusInternalServerError))
+	out, err = c.DailyMulti(ctx, langEN, []string{"US", "GB", "DE"}, nil)
+	assert.Len(t, out, 2)
+	assert.True(t, errors.As(err, &multiErr))
+	assert.Len(t, multiErr, 1)
+	for _, v := range multiErr {
+		assert.True(t, IsServerError(v))
+	}
+}
+
+func TestRealtimeMulti(t *testing.T) {
+	var inFlight, maxInFlight int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte(`)]}'{"storySummaries":{"trendingStories":[{"title":"` + r.URL.Query().Get(paramGeo) + `"}]}}`))
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+	locs := []string{"US", "GB", "DE", "FR", "IN", "JP"}
+
+	out, err := c.RealtimeMulti(context.Background(), langEN, locs, "all", &MultiOptions{Concurrency: 2})
+	assert.NoError(t, err)
+	assert.Len(t, out, len(locs))
+	for _, v := range locs {
+		assert.Equal(t, v, out[v][0].Title)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+
+	_, err = c.RealtimeMulti(context.Background(), langEN, locs, "invalid", nil)
+	assert.Equal(t, ErrInvalidCategory, err)
+}
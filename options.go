@@ -0,0 +1,168 @@
+package gogtrends
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures Client, should be passed to New.
+type Option func(*Client)
+
+// WithLogger sets logger for request details, nothing is logged by default.
+func WithLogger(l Logger) Option {
+	return func(c *Client) {
+		c.setLogger(l)
+	}
+}
+
+// WithLogRedaction enables or disables redaction of tokens and cookies in logs, it's enabled by default.
+func WithLogRedaction(enabled bool) Option {
+	return func(c *Client) {
+		c.redact = enabled
+	}
+}
+
+// WithHTTPClient sets http client used for requests, http.DefaultClient is used by default.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.c = hc
+		}
+	}
+}
+
+// WithBaseURL replaces Google Trends API url, useful for proxies and test servers.
+func WithBaseURL(u string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(u, "/")
+	}
+}
+
+// WithUserAgent sets User-Agent header for every request.
+func WithUserAgent(ua string) Option {
+	return func(c *Client) {
+		c.headers.Set(headerKeyUserAgent, ua)
+	}
+}
+
+// WithHeaders adds headers to every request, existing values with same keys are replaced.
+func WithHeaders(h http.Header) Option {
+	return func(c *Client) {
+		for k, v := range h {
+			c.headers[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+		}
+	}
+}
+
+// WithDefaultParam sets query param sent with Daily and Realtime requests,
+// for example "ri" and "rs" for realtime trends paging.
+func WithDefaultParam(key, value string) Option {
+	return func(c *Client) {
+		c.defParams.Set(key, value)
+	}
+}
+
+// WithRetryPolicy sets retry policy for failed requests, DefaultRetryPolicy is used by default.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		if p.MaxAttempts < 1 {
+			p.MaxAttempts = 1
+		}
+		c.retry = p
+	}
+}
+
+// WithRateLimit limits all client requests to rps requests per second with allowed burst.
+// Requests wait for their turn until context is done.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		c.limiter = newRateLimiter(rps, burst)
+	}
+}
+
+// WithEndpointRateLimit limits requests to specific endpoint (EndpointExplore, EndpointInterestOverTime, etc.),
+// it's applied in addition to WithRateLimit.
+func WithEndpointRateLimit(endpoint string, rps float64, burst int) Option {
+	return func(c *Client) {
+		c.limiters[endpoint] = newRateLimiter(rps, burst)
+	}
+}
+
+// WithCookieJar replaces client session cookie jar. Custom jar can't be saved with SaveCookies.
+func WithCookieJar(jar http.CookieJar) Option {
+	return func(c *Client) {
+		if jar != nil {
+			c.jar = jar
+		}
+	}
+}
+
+// WithCookieFile loads session cookies from file, if it exists, and saves them back on every change,
+// so session survives process restarts.
+func WithCookieFile(file string) Option {
+	return func(c *Client) {
+		jar := newSessionJar()
+		_ = jar.load(file)
+		jar.file = file
+		c.jar = jar
+	}
+}
+
+// WithWarmUpURL replaces url of Google Trends page used by WarmUp to get session cookies.
+func WithWarmUpURL(u string) Option {
+	return func(c *Client) {
+		c.warmUpURL = u
+	}
+}
+
+// WithMiddleware adds middlewares to client requests, they are called in order of adding.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middlewares = append(c.middlewares, mw...)
+	}
+}
+
+// WithMetrics sets receiver of requests statistics.
+func WithMetrics(m Metrics) Option {
+	return func(c *Client) {
+		if m != nil {
+			c.metrics = m
+		}
+	}
+}
+
+// WithCache stores successful responses in cache for ttl, it's applied to all operations
+// unless it's changed with WithCacheTTL. Explore responses contain expiring widget tokens,
+// so they are cached only if ttl is set for OpExplore with WithCacheTTL.
+func WithCache(cache Cache, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cacheDefTTL = ttl
+	}
+}
+
+// WithCacheTTL sets cache ttl for operation, 0 disables cache for it.
+// Notice that Explore responses contain widget tokens which expire after a while.
+func WithCacheTTL(op Operation, ttl time.Duration) Option {
+	return func(c *Client) {
+		c.cacheTTLs[op] = ttl
+	}
+}
+
+// WithPickerTTL sets lifetime of cached ExploreCategories and ExploreLocations trees, 24 hours by default.
+// Zero ttl keeps them until InvalidatePickers is called.
+func WithPickerTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.categories.ttl = ttl
+		c.locations.ttl = ttl
+	}
+}
+
+// WithExploreValidation enables or disables validation of Explore requests before sending, it's enabled by default.
+// Categories and locations are checked only if they are cached by client, use ValidateExplore for full check.
+func WithExploreValidation(enabled bool) Option {
+	return func(c *Client) {
+		c.validate = enabled
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/groovili/gogtrends/otel
+
+go 1.14
+
+replace github.com/groovili/gogtrends => ../
+
+require (
+	github.com/groovili/gogtrends v1.6.0
+	github.com/pkg/errors v0.9.1
+	github.com/stretchr/testify v1.7.1
+	go.opentelemetry.io/otel v1.7.0
+	go.opentelemetry.io/otel/sdk v1.7.0
+	go.opentelemetry.io/otel/trace v1.7.0
+)
@@ -0,0 +1,217 @@
+// Package otel provides OpenTelemetry tracing for gogtrends client.
+// Every API call is recorded as a client span, child of span from request context.
+// Widget calls are linked to span of Explore call which returned the widget,
+// without parent span in context they are also continuing its trace.
+//
+//	client := gogtrends.New(otel.WithTracerProvider(tp))
+package otel
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/groovili/gogtrends"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/groovili/gogtrends/otel"
+	spanPrefix          = "gogtrends."
+
+	paramGeo = "geo"
+
+	// maxExploreSpans is a number of recent Explore widget tokens, which spans are remembered for linking
+	maxExploreSpans = 1024
+)
+
+// Attribute keys of gogtrends spans.
+const (
+	AttrOperation    = attribute.Key("gogtrends.operation")
+	AttrEndpoint     = attribute.Key("gogtrends.endpoint")
+	AttrKeywordCount = attribute.Key("gogtrends.keyword.count")
+	AttrGeo          = attribute.Key("gogtrends.geo")
+	AttrTime         = attribute.Key("gogtrends.time")
+	AttrWidgetID     = attribute.Key("gogtrends.widget.id")
+	AttrStatusCode   = attribute.Key("http.response.status_code")
+)
+
+// WithTracerProvider is a client option which records spans for all requests with provided tracer provider.
+func WithTracerProvider(tp trace.TracerProvider) gogtrends.Option {
+	return gogtrends.WithMiddleware(Middleware(tp))
+}
+
+// Middleware records span for every request passed through it.
+func Middleware(tp trace.TracerProvider) gogtrends.Middleware {
+	tracer := tp.Tracer(instrumentationName)
+	explored := newSpanLinks(maxExploreSpans)
+
+	return func(next gogtrends.Handler) gogtrends.Handler {
+		return func(ctx context.Context, req *gogtrends.Request) (*gogtrends.Response, error) {
+			opts := []trace.SpanStartOption{
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(requestAttributes(req)...),
+			}
+
+			if req.Widget != nil {
+				if sc, ok := explored.get(req.Widget.Token); ok {
+					opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+					if !trace.SpanContextFromContext(ctx).IsValid() {
+						ctx = trace.ContextWithSpanContext(ctx, sc)
+					}
+				}
+			}
+
+			ctx, span := tracer.Start(ctx, spanPrefix+string(req.Operation), opts...)
+			defer span.End()
+
+			resp, err := next(ctx, req)
+			if err != nil {
+				var httpErr *gogtrends.HTTPError
+				if errors.As(err, &httpErr) {
+					span.SetAttributes(AttrStatusCode.Int(httpErr.StatusCode))
+				}
+
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+
+				return nil, err
+			}
+
+			span.SetAttributes(AttrStatusCode.Int(resp.StatusCode))
+
+			if req.Operation == gogtrends.OpExplore {
+				out := new(exploreTokens)
+				if err := resp.Decode(out); err == nil {
+					for _, w := range out.Widgets {
+						explored.add(w.Token, span.SpanContext())
+					}
+				}
+			}
+
+			return resp, nil
+		}
+	}
+}
+
+func requestAttributes(req *gogtrends.Request) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		AttrOperation.String(string(req.Operation)),
+		AttrEndpoint.String(req.Endpoint),
+	}
+
+	geos, times := make([]string, 0), make([]string, 0)
+	keywords := 0
+
+	switch {
+	case req.Explore != nil:
+		keywords = len(req.Explore.ComparisonItems)
+		for _, v := range req.Explore.ComparisonItems {
+			geos = append(geos, v.Geo)
+			times = append(times, v.Time)
+		}
+	case req.Widget != nil:
+		attrs = append(attrs, AttrWidgetID.String(req.Widget.ID))
+		if r := req.Widget.Request; r != nil {
+			items := r.CompItem
+			if len(items) == 0 {
+				items = []*gogtrends.WidgetComparisonItem{&r.Restriction}
+			}
+
+			keywords = len(items)
+			for _, v := range items {
+				for _, g := range v.Geo {
+					geos = append(geos, g)
+				}
+				times = append(times, v.Time)
+			}
+
+			if len(r.Time) > 0 {
+				times = append(times, r.Time)
+			}
+		}
+	default:
+		geos = append(geos, req.Params.Get(paramGeo))
+	}
+
+	if keywords > 0 {
+		attrs = append(attrs, AttrKeywordCount.Int(keywords))
+	}
+
+	if geo := joinUnique(geos); len(geo) > 0 {
+		attrs = append(attrs, AttrGeo.String(geo))
+	}
+
+	if t := joinUnique(times); len(t) > 0 {
+		attrs = append(attrs, AttrTime.String(t))
+	}
+
+	return attrs
+}
+
+// joinUnique joins sorted unique non-empty values.
+func joinUnique(values []string) string {
+	set := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if _, ok := set[v]; ok || len(v) == 0 {
+			continue
+		}
+
+		set[v] = struct{}{}
+		out = append(out, v)
+	}
+	sort.Strings(out)
+
+	return strings.Join(out, ",")
+}
+
+// exploreTokens is a part of Explore response with widget tokens.
+type exploreTokens struct {
+	Widgets []struct {
+		Token string `json:"token"`
+	} `json:"widgets"`
+}
+
+// spanLinks keeps span contexts of limited number of recent widget tokens, the oldest are removed first.
+type spanLinks struct {
+	mu     sync.Mutex
+	size   int
+	spans  map[string]trace.SpanContext
+	tokens []string
+}
+
+func newSpanLinks(size int) *spanLinks {
+	return &spanLinks{size: size, spans: make(map[string]trace.SpanContext, size)}
+}
+
+func (l *spanLinks) add(token string, sc trace.SpanContext) {
+	if len(token) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.spans[token]; !ok {
+		l.tokens = append(l.tokens, token)
+	}
+	l.spans[token] = sc
+
+	for len(l.tokens) > l.size {
+		delete(l.spans, l.tokens[0])
+		l.tokens = l.tokens[1:]
+	}
+}
+
+func (l *spanLinks) get(token string) (trace.SpanContext, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sc, ok := l.spans[token]
+	return sc, ok
+}
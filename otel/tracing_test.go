@@ -0,0 +1,152 @@
+package otel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/groovili/gogtrends"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+const explorePayload = `)]}'{"widgets":[{"token":"t","id":"TIMESERIES","request":{"time":"today 12-m"}}]}`
+
+func TestTracing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == gogtrends.EndpointExplore {
+			_, _ = w.Write([]byte(explorePayload))
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c := gogtrends.New(
+		gogtrends.WithBaseURL(srv.URL),
+		gogtrends.WithRetryPolicy(gogtrends.RetryPolicy{MaxAttempts: 1}),
+		WithTracerProvider(tp),
+	)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "dashboard")
+
+	_, err := c.Explore(ctx, &gogtrends.ExploreRequest{
+		ComparisonItems: []*gogtrends.ComparisonItem{
+			{Keyword: "Go", Geo: "US", Time: "today 12-m"},
+			{Keyword: "Rust", Geo: "US", Time: "today 12-m"},
+		},
+	}, "EN")
+	assert.NoError(t, err)
+
+	_, err = c.Daily(ctx, "EN", "GB")
+	assert.Error(t, err)
+	parent.End()
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 3)
+
+	explore, daily := spans[0], spans[1]
+	assert.Equal(t, "gogtrends.Explore", explore.Name)
+	assert.Equal(t, parent.SpanContext().SpanID(), explore.Parent.SpanID())
+	assert.Equal(t, parent.SpanContext().TraceID(), daily.SpanContext.TraceID())
+
+	attrs := attrMap(explore.Attributes)
+	assert.Equal(t, int64(2), attrs[AttrKeywordCount].AsInt64())
+	assert.Equal(t, "US", attrs[AttrGeo].AsString())
+	assert.Equal(t, "today 12-m", attrs[AttrTime].AsString())
+	assert.Equal(t, int64(http.StatusOK), attrs[AttrStatusCode].AsInt64())
+
+	assert.Equal(t, "gogtrends.Daily", daily.Name)
+	assert.Equal(t, codes.Error, daily.Status.Code)
+	attrs = attrMap(daily.Attributes)
+	assert.Equal(t, "GB", attrs[AttrGeo].AsString())
+	assert.Equal(t, int64(http.StatusBadRequest), attrs[AttrStatusCode].AsInt64())
+}
+
+func TestExploreWidgetLinks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == gogtrends.EndpointExplore {
+			_, _ = w.Write([]byte(explorePayload))
+			return
+		}
+
+		_, _ = w.Write([]byte(`)]}'{"default":{"timelineData":[]}}`))
+	}))
+	defer srv.Close()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	c := gogtrends.New(gogtrends.WithBaseURL(srv.URL), WithTracerProvider(tp))
+
+	// widget call continues trace of explore without parent span
+	ctx := context.Background()
+	widgets, err := c.Explore(ctx, &gogtrends.ExploreRequest{
+		ComparisonItems: []*gogtrends.ComparisonItem{{Keyword: "Go", Time: "today 12-m"}},
+	}, "EN")
+	assert.NoError(t, err)
+
+	_, err = c.InterestOverTime(ctx, widgets[0], "EN")
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+
+	explore, timeline := spans[0], spans[1]
+	assert.Equal(t, "gogtrends.InterestOverTime", timeline.Name)
+	assert.Len(t, timeline.Links, 1)
+	assert.Equal(t, explore.SpanContext.SpanID(), timeline.Links[0].SpanContext.SpanID())
+	assert.Equal(t, explore.SpanContext.SpanID(), timeline.Parent.SpanID())
+	assert.Equal(t, explore.SpanContext.TraceID(), timeline.SpanContext.TraceID())
+
+	// parent span of context is kept, explore span is linked
+	exporter.Reset()
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "dashboard")
+	_, err = c.InterestOverTime(ctx, widgets[0], "EN")
+	assert.NoError(t, err)
+	parent.End()
+
+	spans = exporter.GetSpans()
+	assert.Len(t, spans, 2)
+
+	timeline = spans[0]
+	assert.Equal(t, parent.SpanContext().SpanID(), timeline.Parent.SpanID())
+	assert.Len(t, timeline.Links, 1)
+	assert.Equal(t, explore.SpanContext.SpanID(), timeline.Links[0].SpanContext.SpanID())
+}
+
+func TestWidgetAttributes(t *testing.T) {
+	attrs := attrMap(requestAttributes(&gogtrends.Request{
+		Operation: gogtrends.OpRelated,
+		Widget: &gogtrends.ExploreWidget{
+			ID: "RELATED_QUERIES_0",
+			Request: &gogtrends.WidgetResponse{
+				Restriction: gogtrends.WidgetComparisonItem{
+					Geo:  map[string]string{"country": "US"},
+					Time: "2020-01-01 2020-06-01",
+				},
+			},
+		},
+	}))
+
+	assert.Equal(t, "RELATED_QUERIES_0", attrs[AttrWidgetID].AsString())
+	assert.Equal(t, int64(1), attrs[AttrKeywordCount].AsInt64())
+	assert.Equal(t, "US", attrs[AttrGeo].AsString())
+	assert.Equal(t, "2020-01-01 2020-06-01", attrs[AttrTime].AsString())
+}
+
+func attrMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	out := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, v := range attrs {
+		out[v.Key] = v.Value
+	}
+
+	return out
+}
@@ -0,0 +1,90 @@
+package gogtrends
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// defaultPickerTTL is a lifetime of cached categories and locations trees.
+const defaultPickerTTL = 24 * time.Hour
+
+type pickerEntry struct {
+	tree    interface{}
+	expires time.Time
+}
+
+// pickerCache keeps explore picker trees by language until ttl expires, 0 ttl means forever.
+type pickerCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[string]*pickerEntry
+}
+
+func newPickerCache(ttl time.Duration) *pickerCache {
+	return &pickerCache{
+		ttl:     ttl,
+		entries: make(map[string]*pickerEntry),
+	}
+}
+
+func (p *pickerCache) get(hl string) (interface{}, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	e, ok := p.entries[hl]
+	if !ok || (!e.expires.IsZero() && !e.expires.After(time.Now())) {
+		return nil, false
+	}
+
+	return e.tree, true
+}
+
+// anyLang returns tree in any language, ids of categories and locations are the same in all of them.
+func (p *pickerCache) anyLang() (interface{}, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for _, e := range p.entries {
+		if e.expires.IsZero() || e.expires.After(time.Now()) {
+			return e.tree, true
+		}
+	}
+
+	return nil, false
+}
+
+func (p *pickerCache) set(hl string, tree interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e := &pickerEntry{tree: tree}
+	if p.ttl > 0 {
+		e.expires = time.Now().Add(p.ttl)
+	}
+	p.entries[hl] = e
+}
+
+func (p *pickerCache) invalidate() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.entries = make(map[string]*pickerEntry)
+}
+
+// picker requests explore picker in hl language into dest, empty hl is omitted.
+func (c *Client) picker(ctx context.Context, op Operation, endpoint, hl string, dest interface{}) error {
+	u, err := c.url(endpoint)
+	if err != nil {
+		return err
+	}
+
+	req := &Request{Operation: op, Endpoint: endpoint, URL: u, noCache: true}
+	if len(hl) > 0 {
+		req.Params = make(url.Values)
+		req.Params.Set(paramHl, hl)
+	}
+
+	return c.do(ctx, req, dest)
+}
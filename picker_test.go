@@ -0,0 +1,73 @@
+package gogtrends
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientPickers(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+
+		name := "All categories"
+		if r.URL.Query().Get(paramHl) == "de" {
+			name = "Alle Kategorien"
+		}
+
+		if r.URL.Path == gSGeo {
+			_, _ = w.Write([]byte(`)]}'{"name":"` + name + `","id":"","children":[{"name":"US","id":"US"}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`)]}'{"name":"` + name + `","id":0,"children":[{"name":"Programming","id":31}]}`))
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL), WithPickerTTL(time.Hour))
+	ctx := context.Background()
+
+	cats, err := c.ExploreCategories(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "All categories", cats.Name)
+
+	cats, err = c.ExploreCategoriesLang(ctx, "de")
+	assert.NoError(t, err)
+	assert.Equal(t, "Alle Kategorien", cats.Name)
+
+	_, err = c.ExploreCategoriesLang(ctx, "de")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	_, err = c.RefreshCategories(ctx, "de")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	locs, err := c.ExploreLocations(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, locs.Children, 1)
+	_, err = c.ExploreLocations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+
+	c.InvalidatePickers()
+	_, err = c.ExploreCategories(ctx)
+	assert.NoError(t, err)
+	_, err = c.ExploreLocations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(6), atomic.LoadInt32(&calls))
+
+	// expired trees are requested again
+	c = New(WithBaseURL(srv.URL), WithPickerTTL(time.Nanosecond))
+	for i := 0; i < 2; i++ {
+		_, err = c.ExploreCategories(ctx)
+		assert.NoError(t, err)
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, int32(8), atomic.LoadInt32(&calls))
+}
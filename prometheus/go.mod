@@ -0,0 +1,11 @@
+module github.com/groovili/gogtrends/prometheus
+
+go 1.14
+
+replace github.com/groovili/gogtrends => ../
+
+require (
+	github.com/groovili/gogtrends v1.6.0
+	github.com/prometheus/client_golang v1.11.1
+	github.com/stretchr/testify v1.7.0
+)
@@ -0,0 +1,145 @@
+// Package prometheus provides gogtrends.Metrics implementation with Prometheus collectors.
+//
+//	m := prometheus.New("myapp")
+//	registry.MustRegister(m)
+//	client := gogtrends.New(gogtrends.WithMetrics(m))
+package prometheus
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/groovili/gogtrends"
+	prom "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	subsystem = "gogtrends"
+
+	labelOperation = "operation"
+	labelCode      = "code"
+
+	// codeNoResponse is a code label for requests failed without response
+	codeNoResponse = "none"
+)
+
+// Metrics collects statistics of gogtrends client requests, it implements gogtrends.Metrics and prometheus.Collector.
+type Metrics struct {
+	requests        *prom.CounterVec
+	failures        *prom.CounterVec
+	rateLimited     *prom.CounterVec
+	retries         *prom.CounterVec
+	cookieRefreshes *prom.CounterVec
+	latency         *prom.HistogramVec
+	size            *prom.HistogramVec
+}
+
+var _ gogtrends.Metrics = (*Metrics)(nil)
+
+// New creates collectors with provided namespace, Metrics should be registered in prometheus registry.
+func New(namespace string) *Metrics {
+	return &Metrics{
+		requests: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "Number of Google Trends API requests by operation and final status code.",
+		}, []string{labelOperation, labelCode}),
+		failures: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_failures_total",
+			Help:      "Number of failed Google Trends API requests by operation and final status code.",
+		}, []string{labelOperation, labelCode}),
+		rateLimited: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "rate_limited_total",
+			Help:      "Number of 429 responses including retried attempts.",
+		}, []string{labelOperation}),
+		retries: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "retries_total",
+			Help:      "Number of repeated attempts by operation and status code of the previous one.",
+		}, []string{labelOperation, labelCode}),
+		cookieRefreshes: prom.NewCounterVec(prom.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cookie_refreshes_total",
+			Help:      "Number of session cookie refreshes requested by Google.",
+		}, []string{labelOperation}),
+		latency: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of Google Trends API requests including retries.",
+			Buckets:   prom.DefBuckets,
+		}, []string{labelOperation}),
+		size: prom.NewHistogramVec(prom.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "response_size_bytes",
+			Help:      "Size of Google Trends API response payloads.",
+			Buckets:   prom.ExponentialBuckets(256, 4, 8),
+		}, []string{labelOperation}),
+	}
+}
+
+// RequestDone implements gogtrends.Metrics.
+func (m *Metrics) RequestDone(op gogtrends.Operation, status int, failed bool, latency time.Duration, size int) {
+	code := codeLabel(status)
+
+	m.requests.WithLabelValues(string(op), code).Inc()
+	m.latency.WithLabelValues(string(op)).Observe(latency.Seconds())
+
+	if failed {
+		m.failures.WithLabelValues(string(op), code).Inc()
+	} else {
+		m.size.WithLabelValues(string(op)).Observe(float64(size))
+	}
+
+	if status == 429 {
+		m.rateLimited.WithLabelValues(string(op)).Inc()
+	}
+}
+
+// Retried implements gogtrends.Metrics.
+func (m *Metrics) Retried(op gogtrends.Operation, status int) {
+	m.retries.WithLabelValues(string(op), codeLabel(status)).Inc()
+
+	if status == 429 {
+		m.rateLimited.WithLabelValues(string(op)).Inc()
+	}
+}
+
+// CookiesRefreshed implements gogtrends.Metrics.
+func (m *Metrics) CookiesRefreshed(op gogtrends.Operation) {
+	m.cookieRefreshes.WithLabelValues(string(op)).Inc()
+}
+
+// Describe implements prometheus.Collector.
+func (m *Metrics) Describe(ch chan<- *prom.Desc) {
+	for _, c := range m.collectors() {
+		c.Describe(ch)
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (m *Metrics) Collect(ch chan<- prom.Metric) {
+	for _, c := range m.collectors() {
+		c.Collect(ch)
+	}
+}
+
+func (m *Metrics) collectors() []prom.Collector {
+	return []prom.Collector{m.requests, m.failures, m.rateLimited, m.retries, m.cookieRefreshes, m.latency, m.size}
+}
+
+func codeLabel(status int) string {
+	if status == 0 {
+		return codeNoResponse
+	}
+
+	return strconv.Itoa(status)
+}
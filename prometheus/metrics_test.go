@@ -0,0 +1,61 @@
+package prometheus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/groovili/gogtrends"
+	prom "github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+const dailyPayload = `)]}',{"default":{"trendingSearchesDays":[{"trendingSearches":[{"title":{"query":"Golang"}}]}]}}`
+
+func TestMetrics(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == gogtrends.EndpointExplore {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if _, err := r.Cookie("NID"); err != nil {
+			http.SetCookie(w, &http.Cookie{Name: "NID", Value: "session"})
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = w.Write([]byte(dailyPayload))
+	}))
+	defer srv.Close()
+
+	m := New("test")
+	reg := prom.NewPedanticRegistry()
+	assert.NoError(t, reg.Register(m))
+
+	c := gogtrends.New(gogtrends.WithBaseURL(srv.URL), gogtrends.WithMetrics(m))
+
+	_, err := c.Daily(context.Background(), "EN", "US")
+	assert.NoError(t, err)
+
+	_, err = c.Explore(context.Background(), &gogtrends.ExploreRequest{
+		ComparisonItems: []*gogtrends.ComparisonItem{{Keyword: "Golang", Time: "today 12-m"}},
+	}, "EN")
+	assert.Error(t, err)
+
+	daily, explore := string(gogtrends.OpDaily), string(gogtrends.OpExplore)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues(daily, "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.retries.WithLabelValues(daily, "429")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.rateLimited.WithLabelValues(daily)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cookieRefreshes.WithLabelValues(daily)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.requests.WithLabelValues(explore, "400")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.failures.WithLabelValues(explore, "400")))
+
+	// one series for every histogram with samples
+	count, err := testutil.GatherAndCount(reg, "test_gogtrends_request_duration_seconds", "test_gogtrends_response_size_bytes")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, count)
+}
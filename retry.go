@@ -0,0 +1,134 @@
+package gogtrends
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const headerKeyRetryAfter = "Retry-After"
+
+// RetryPolicy describes when and how often failed requests are repeated.
+// Policy is applied to every request of the Client.
+type RetryPolicy struct {
+	// MaxAttempts is a total number of attempts including the first one, 1 disables retries.
+	MaxAttempts int
+	// MinBackoff is a delay before the first retry, it's doubled for every next one.
+	MinBackoff time.Duration
+	// MaxBackoff limits delay between attempts, 0 means no limit. If Retry-After header asks to wait longer,
+	// request is not retried.
+	MaxBackoff time.Duration
+	// Jitter is a random part added to every delay, fraction of delay from 0 to 1.
+	Jitter float64
+	// RetryableStatuses is a list of response codes which are retried.
+	RetryableStatuses []int
+	// ShouldRetry overrides default decision for response or error, resp is nil when request failed with err.
+	// Default decision is to retry all transport errors and RetryableStatuses.
+	ShouldRetry func(resp *http.Response, err error) bool
+}
+
+// DefaultRetryPolicy returns policy used by Client by default: three attempts
+// for transport errors, 429 and 5xx responses.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// retryable checks if request can be repeated after attempt with provided outcome.
+func (p RetryPolicy) retryable(ctx context.Context, attempt int, resp *http.Response, err error) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(resp, err)
+	}
+
+	if err != nil {
+		return true
+	}
+
+	for _, v := range p.RetryableStatuses {
+		if resp.StatusCode == v {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff is an exponential delay with jitter after provided attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff) && d < math.MaxInt64/2; i++ {
+		d *= 2
+	}
+
+	if p.Jitter > 0 && d > 0 {
+		// not limited delay is close to max duration after many attempts, jitter must not overflow it
+		if j := rand.Float64() * p.Jitter * float64(d); j < float64(math.MaxInt64-d) {
+			d += time.Duration(j)
+		} else {
+			d = math.MaxInt64
+		}
+	}
+
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	return d
+}
+
+// retryAfter parses Retry-After header in seconds or http date format.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get(headerKeyRetryAfter)
+	if len(v) == 0 {
+		return 0, false
+	}
+
+	if sec, err := strconv.Atoi(v); err == nil && sec >= 0 {
+		return time.Duration(sec) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// sleep waits for provided duration or until context is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,318 @@
+package gogtrends
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+const (
+	gWarmUp = "https://trends.google.com/trends/explore"
+
+	errSaveCookies = "failed to save cookies"
+	errLoadCookies = "failed to load cookies"
+)
+
+// ErrCookieJarNotPersistent - cookies can't be saved or loaded, because client uses custom cookie jar
+var ErrCookieJarNotPersistent = errors.New("cookie jar is not persistent")
+
+// sessionCookie is a stored cookie, exported fields are used for persistence.
+type sessionCookie struct {
+	Name     string    `json:"name"`
+	Value    string    `json:"value"`
+	Domain   string    `json:"domain"`
+	Path     string    `json:"path"`
+	Expires  time.Time `json:"expires,omitempty"`
+	Secure   bool      `json:"secure,omitempty"`
+	HostOnly bool      `json:"hostOnly,omitempty"`
+}
+
+func (c *sessionCookie) key() string {
+	return c.Domain + ";" + c.Path + ";" + c.Name
+}
+
+func (c *sessionCookie) equal(o *sessionCookie) bool {
+	return c.Value == o.Value && c.Secure == o.Secure && c.HostOnly == o.HostOnly && c.Expires.Equal(o.Expires)
+}
+
+func (c *sessionCookie) expired(now time.Time) bool {
+	return !c.Expires.IsZero() && !c.Expires.After(now)
+}
+
+func (c *sessionCookie) match(u *url.URL) bool {
+	host := u.Hostname()
+	if c.HostOnly {
+		if host != c.Domain {
+			return false
+		}
+	} else if host != c.Domain && !strings.HasSuffix(host, "."+c.Domain) {
+		return false
+	}
+
+	if c.Secure && u.Scheme != "https" {
+		return false
+	}
+
+	p := u.Path
+	if len(p) == 0 {
+		p = "/"
+	}
+
+	return strings.HasPrefix(p, c.Path)
+}
+
+// sessionJar is a concurrent safe http.CookieJar, which can be saved to file and loaded back.
+// If file is set, jar is saved when any cookie is changed.
+type sessionJar struct {
+	mu      sync.RWMutex
+	cookies map[string]*sessionCookie
+	file    string
+}
+
+func newSessionJar() *sessionJar {
+	return &sessionJar{cookies: make(map[string]*sessionCookie)}
+}
+
+// SetCookies implements http.CookieJar. Cookies for domains, which don't match u host, are ignored.
+func (j *sessionJar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	if len(cookies) == 0 {
+		return
+	}
+
+	now := time.Now()
+	host := strings.ToLower(u.Hostname())
+	changed := false
+
+	j.mu.Lock()
+	for _, v := range cookies {
+		domain, hostOnly, ok := cookieDomain(host, v.Domain)
+		if !ok {
+			continue
+		}
+
+		sc := &sessionCookie{
+			Name:     v.Name,
+			Value:    v.Value,
+			Domain:   domain,
+			Path:     v.Path,
+			Expires:  v.Expires,
+			Secure:   v.Secure,
+			HostOnly: hostOnly,
+		}
+
+		if len(sc.Path) == 0 || sc.Path[0] != '/' {
+			sc.Path = "/"
+		}
+
+		switch {
+		case v.MaxAge < 0:
+			sc.Expires = now
+		case v.MaxAge > 0:
+			sc.Expires = now.Add(time.Duration(v.MaxAge) * time.Second)
+		}
+
+		old, exists := j.cookies[sc.key()]
+		if sc.expired(now) {
+			if exists {
+				delete(j.cookies, sc.key())
+				changed = true
+			}
+			continue
+		}
+
+		if !exists || !old.equal(sc) {
+			j.cookies[sc.key()] = sc
+			changed = true
+		}
+	}
+	file := j.file
+	j.mu.Unlock()
+
+	if changed && len(file) > 0 {
+		// persistence is best effort here, explicit Client.SaveCookies reports errors
+		_ = j.save(file)
+	}
+}
+
+// cookieDomain returns domain of cookie set by host with domain attribute and if cookie is host only.
+// Like in net/http/cookiejar domain must match host and can't be a top level domain or ip address.
+func cookieDomain(host, attr string) (string, bool, bool) {
+	domain := strings.TrimPrefix(strings.ToLower(attr), ".")
+	ip := net.ParseIP(host) != nil
+	tld := !strings.Contains(domain, ".")
+
+	if len(domain) == 0 || domain == host && (ip || tld) {
+		return host, true, true
+	}
+
+	if ip || tld {
+		return "", false, false
+	}
+
+	if host != domain && !strings.HasSuffix(host, "."+domain) {
+		return "", false, false
+	}
+
+	return domain, false, true
+}
+
+// Cookies implements http.CookieJar.
+func (j *sessionJar) Cookies(u *url.URL) []*http.Cookie {
+	now := time.Now()
+
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	out := make([]*http.Cookie, 0, len(j.cookies))
+	for _, v := range j.cookies {
+		if v.expired(now) || !v.match(u) {
+			continue
+		}
+
+		out = append(out, &http.Cookie{Name: v.Name, Value: v.Value})
+	}
+
+	return out
+}
+
+func (j *sessionJar) write(w io.Writer) error {
+	now := time.Now()
+
+	j.mu.RLock()
+	list := make([]*sessionCookie, 0, len(j.cookies))
+	for _, v := range j.cookies {
+		if !v.expired(now) {
+			list = append(list, v)
+		}
+	}
+	j.mu.RUnlock()
+
+	return jsoniter.NewEncoder(w).Encode(list)
+}
+
+func (j *sessionJar) read(r io.Reader) error {
+	list := make([]*sessionCookie, 0)
+	if err := jsoniter.NewDecoder(r).Decode(&list); err != nil {
+		return errors.Wrap(err, errParsing)
+	}
+
+	now := time.Now()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, v := range list {
+		if !v.expired(now) {
+			j.cookies[v.key()] = v
+		}
+	}
+
+	return nil
+}
+
+// save writes cookies to temp file and renames it, so file is never partially written.
+func (j *sessionJar) save(file string) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".*")
+	if err != nil {
+		return errors.Wrap(err, errSaveCookies)
+	}
+
+	if err := j.write(tmp); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, errSaveCookies)
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return errors.Wrap(err, errSaveCookies)
+	}
+
+	return errors.Wrap(os.Rename(tmp.Name(), file), errSaveCookies)
+}
+
+func (j *sessionJar) load(file string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return errors.Wrap(err, errLoadCookies)
+	}
+	defer f.Close()
+
+	return errors.Wrap(j.read(f), errLoadCookies)
+}
+
+// SaveCookies writes client session cookies to file.
+func (c *Client) SaveCookies(file string) error {
+	jar, ok := c.jar.(*sessionJar)
+	if !ok {
+		return ErrCookieJarNotPersistent
+	}
+
+	return jar.save(file)
+}
+
+// LoadCookies reads session cookies saved by SaveCookies to the client.
+func (c *Client) LoadCookies(file string) error {
+	jar, ok := c.jar.(*sessionJar)
+	if !ok {
+		return ErrCookieJarNotPersistent
+	}
+
+	return jar.load(file)
+}
+
+// WarmUp opens Google Trends explore page to get session cookies before API calls,
+// it decreases chance of 429 response for the first requests.
+func (c *Client) WarmUp(ctx context.Context) error {
+	if err := c.wait(ctx, ""); err != nil {
+		return errors.Wrap(err, errDoRequest)
+	}
+
+	u, err := url.Parse(c.warmUpURL)
+	if err != nil {
+		return errors.Wrap(err, errCreateRequest)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return errors.Wrap(err, errCreateRequest)
+	}
+
+	for k, v := range c.headers {
+		r.Header[k] = v
+	}
+
+	for _, v := range c.jar.Cookies(u) {
+		r.AddCookie(v)
+	}
+
+	resp, err := c.c.Do(r)
+	if err != nil {
+		return errors.Wrap(err, errDoRequest)
+	}
+	defer resp.Body.Close()
+
+	cookies := resp.Cookies()
+	c.jar.SetCookies(u, cookies)
+
+	// google answers with 429 and cookies to repeat request with, it's still a warm up
+	if len(cookies) == 0 && (resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices) {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, errBodyExcerptLen))
+		return newHTTPError(u.Path, u, resp, body)
+	}
+
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	return nil
+}
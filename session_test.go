@@ -0,0 +1,149 @@
+package gogtrends
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCookieSession(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("NID"); err != nil {
+			http.SetCookie(w, &http.Cookie{Name: "NID", Value: "session", Path: "/", MaxAge: 3600})
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+
+		_, _ = w.Write([]byte(dailyPayload))
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL), WithWarmUpURL(srv.URL+"/explore"))
+
+	wg := new(sync.WaitGroup)
+	wg.Add(concurrentGoroutinesNum)
+	for i := 0; i < concurrentGoroutinesNum; i++ {
+		go func() {
+			defer wg.Done()
+
+			resp, err := c.Daily(context.Background(), langEN, locUS)
+			assert.NoError(t, err)
+			assert.Len(t, resp, 1)
+		}()
+	}
+	wg.Wait()
+
+	// warm up stores session before the first request
+	c = New(WithBaseURL(srv.URL), WithWarmUpURL(srv.URL+"/explore"), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	_, err := c.Daily(context.Background(), langEN, locUS)
+	assert.True(t, IsRateLimited(err))
+
+	c = New(WithBaseURL(srv.URL), WithWarmUpURL(srv.URL+"/explore"), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	assert.NoError(t, c.WarmUp(context.Background()))
+	_, err = c.Daily(context.Background(), langEN, locUS)
+	assert.NoError(t, err)
+}
+
+func TestClientCookieFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gogtrends")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "cookies.json")
+	u, _ := url.Parse(gAPI)
+
+	c := New(WithCookieFile(file))
+	c.jar.SetCookies(u, []*http.Cookie{
+		{Name: "NID", Value: "session", Domain: ".google.com", Path: "/"},
+		{Name: "expired", Value: "old", Expires: time.Now().Add(-time.Hour)},
+	})
+
+	// saved automatically on change
+	c = New(WithCookieFile(file))
+	cookies := c.jar.Cookies(u)
+	assert.Len(t, cookies, 1)
+	assert.Equal(t, "session", cookies[0].Value)
+
+	// other hosts don't get session cookies
+	other, _ := url.Parse("https://example.com/trends")
+	assert.Len(t, c.jar.Cookies(other), 0)
+
+	// explicit save and load
+	file = filepath.Join(dir, "saved.json")
+	assert.NoError(t, c.SaveCookies(file))
+
+	c = New()
+	assert.Error(t, c.LoadCookies(filepath.Join(dir, "unknown.json")))
+	assert.NoError(t, c.LoadCookies(file))
+	assert.Len(t, c.jar.Cookies(u), 1)
+
+	c = New(WithCookieJar(newSessionJar()))
+	assert.NoError(t, c.SaveCookies(file))
+	c = New(WithCookieJar(&cookieJarStub{}))
+	assert.Equal(t, ErrCookieJarNotPersistent, c.SaveCookies(file))
+}
+
+func TestSessionJarDomain(t *testing.T) {
+	j := newSessionJar()
+	u, _ := url.Parse(gAPI)
+	j.SetCookies(u, []*http.Cookie{
+		{Name: "NID", Value: "session", Domain: ".google.com"},
+		{Name: "host", Value: "trends"},
+		{Name: "other", Value: "planted", Domain: "example.com"},
+		{Name: "sibling", Value: "planted", Domain: "mail.google.com"},
+		{Name: "tld", Value: "planted", Domain: "com"},
+	})
+
+	assert.Len(t, j.Cookies(u), 2)
+	other, _ := url.Parse("https://example.com/")
+	assert.Len(t, j.Cookies(other), 0)
+	mail, _ := url.Parse("https://mail.google.com/")
+	assert.Len(t, j.Cookies(mail), 1)
+
+	// ip address hosts get only host cookies
+	ip, _ := url.Parse("http://127.0.0.1:8080/")
+	j.SetCookies(ip, []*http.Cookie{
+		{Name: "host", Value: "local"},
+		{Name: "domain", Value: "local", Domain: "127.0.0.1"},
+		{Name: "other", Value: "planted", Domain: "0.1"},
+	})
+	assert.Len(t, j.Cookies(ip), 2)
+}
+
+func TestSessionJarSaveOnChange(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gogtrends")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "cookies.json")
+	u, _ := url.Parse(gAPI)
+	expires := time.Now().Add(time.Hour)
+
+	c := New(WithCookieFile(file))
+	c.jar.SetCookies(u, []*http.Cookie{{Name: "NID", Value: "session", Expires: expires}})
+	assert.FileExists(t, file)
+
+	// the same cookie doesn't rewrite file
+	assert.NoError(t, os.Remove(file))
+	c.jar.SetCookies(u, []*http.Cookie{{Name: "NID", Value: "session", Expires: expires}})
+	_, err = os.Stat(file)
+	assert.True(t, os.IsNotExist(err))
+
+	c.jar.SetCookies(u, []*http.Cookie{{Name: "NID", Value: "refreshed", Expires: expires}})
+	assert.FileExists(t, file)
+}
+
+type cookieJarStub struct{}
+
+func (j *cookieJarStub) SetCookies(u *url.URL, cookies []*http.Cookie) {}
+
+func (j *cookieJarStub) Cookies(u *url.URL) []*http.Cookie { return nil }
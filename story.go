@@ -0,0 +1,152 @@
+package gogtrends
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// realtime gets first page of realtime stories with ids of all stories.
+func (c *Client) realtime(ctx context.Context, hl, loc, cat string) (*realtimeOut, error) {
+	if !c.validateCategory(cat) {
+		return nil, ErrInvalidCategory
+	}
+
+	out := new(realtimeOut)
+	if err := c.trends(ctx, OpRealtime, gRealtime, hl, loc, out, map[string]string{paramCat: cat}); err != nil {
+		return nil, err
+	}
+
+	if out.StorySummaries == nil {
+		out.StorySummaries = new(storySummary)
+	}
+
+	return out, nil
+}
+
+// RealtimeAll gets realtime stories beyond the first page, in order of trending board.
+// Stories missing in the first page are requested by ids in batches, limit <= 0 returns all stories.
+func (c *Client) RealtimeAll(ctx context.Context, hl, loc, cat string, limit int) ([]*TrendingStory, error) {
+	out, err := c.realtime(ctx, hl, loc, cat)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := out.TrendingStoryIDs
+	if limit > 0 && len(ids) > limit {
+		ids = ids[:limit]
+	}
+
+	byID := make(map[string]*TrendingStory, len(ids))
+	for _, v := range out.StorySummaries.TrendingStories {
+		byID[v.ID] = v
+	}
+
+	missing := make([]string, 0)
+	for _, id := range ids {
+		if _, ok := byID[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+
+	for i := 0; i < len(missing); i += storySummaryBatch {
+		end := i + storySummaryBatch
+		if end > len(missing) {
+			end = len(missing)
+		}
+
+		stories, err := c.storySummary(ctx, hl, loc, cat, missing[i:end])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range stories {
+			byID[v.ID] = v
+		}
+	}
+
+	// keep order of trending board, stories without ids are from the first page only
+	if len(ids) == 0 {
+		stories := out.StorySummaries.TrendingStories
+		if limit > 0 && len(stories) > limit {
+			stories = stories[:limit]
+		}
+
+		return stories, nil
+	}
+
+	stories := make([]*TrendingStory, 0, len(ids))
+	for _, id := range ids {
+		if v, ok := byID[id]; ok {
+			stories = append(stories, v)
+		}
+	}
+
+	return stories, nil
+}
+
+// storySummary gets realtime stories by ids.
+func (c *Client) storySummary(ctx context.Context, hl, loc, cat string, ids []string) ([]*TrendingStory, error) {
+	u, err := c.url(gStorySummary)
+	if err != nil {
+		return nil, err
+	}
+
+	p := make(url.Values)
+	p.Set(paramTZ, "0")
+	p.Set(paramHl, hl)
+	p.Set(paramCat, cat)
+	if len(loc) > 0 {
+		p.Set(paramGeo, loc)
+	}
+
+	for _, id := range ids {
+		p.Add(paramID, id)
+	}
+
+	out := new(storySummary)
+	if err := c.do(ctx, &Request{Operation: OpStorySummary, Endpoint: gStorySummary, URL: u, Params: p}, out); err != nil {
+		return nil, err
+	}
+
+	return out.TrendingStories, nil
+}
+
+// StoryDetails gets realtime story by its id with interest over time, articles and related queries.
+// Story widgets are resolved with InterestOverTime and Related requests.
+func (c *Client) StoryDetails(ctx context.Context, id, hl string) (*Story, error) {
+	u, err := c.url(fmt.Sprintf("%s/%s", gStories, url.PathEscape(id)))
+	if err != nil {
+		return nil, err
+	}
+
+	p := make(url.Values)
+	p.Set(paramTZ, "0")
+	p.Set(paramHl, hl)
+
+	out := new(storyOut)
+	if err := c.do(ctx, &Request{Operation: OpStoryDetails, Endpoint: gStories, URL: u, Params: p}, out); err != nil {
+		return nil, err
+	}
+
+	story := &Story{ID: id, Title: out.Title}
+	for _, w := range out.Widgets {
+		switch {
+		case strings.HasPrefix(w.ID, string(NewsArticlesID)):
+			story.Articles = append(story.Articles, w.Articles...)
+		case w.Request == nil:
+			continue
+		case strings.HasPrefix(w.ID, string(IntOverTimeWidgetID)):
+			story.Timeline, err = c.InterestOverTime(ctx, &w.ExploreWidget, hl)
+		case strings.HasPrefix(w.ID, string(RelatedQueriesID)):
+			story.RelatedQueries, err = c.Related(ctx, &w.ExploreWidget, hl)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return story, nil
+}
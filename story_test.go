@@ -0,0 +1,68 @@
+package gogtrends
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/groovili/gogtrends/gogtrendstest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoryDetails(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	stories, err := c.Realtime(ctx, langEN, locUS, "all")
+	assert.NoError(t, err)
+
+	s := stories[0]
+	assert.NotEmpty(t, s.ID)
+	assert.NotEmpty(t, s.EntityNames)
+	assert.NotEmpty(t, s.ShareURL)
+
+	story, err := c.StoryDetails(ctx, s.ID, langEN)
+	assert.NoError(t, err)
+	assert.Equal(t, s.ID, story.ID)
+	assert.Equal(t, s.Title, story.Title)
+	assert.NotEmpty(t, story.Timeline)
+	assert.Len(t, story.Articles, 2)
+	assert.NotEmpty(t, story.RelatedQueries)
+
+	assert.Equal(t, 1, srv.Requests(gogtrendstest.PathStories))
+	assert.Equal(t, 1, srv.Requests(gogtrendstest.PathMultiline))
+	assert.Equal(t, 1, srv.Requests(gogtrendstest.PathRelated))
+}
+
+func TestRealtimeAll(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	stories, err := c.RealtimeAll(ctx, langEN, locUS, "all", 0)
+	assert.NoError(t, err)
+	assert.Len(t, stories, 50)
+	for i, v := range stories {
+		assert.Equal(t, fmt.Sprintf("US_lnk_story%d_en", i), v.ID)
+	}
+	// 40 stories beyond the first page in batches of 20
+	assert.Equal(t, 2, srv.Requests(gogtrendstest.PathStorySummary))
+
+	stories, err = c.RealtimeAll(ctx, langEN, locUS, "all", 25)
+	assert.NoError(t, err)
+	assert.Len(t, stories, 25)
+	assert.Equal(t, 3, srv.Requests(gogtrendstest.PathStorySummary))
+
+	stories, err = c.RealtimeAll(ctx, langEN, locUS, "all", 5)
+	assert.NoError(t, err)
+	assert.Len(t, stories, 5)
+	assert.Equal(t, 3, srv.Requests(gogtrendstest.PathStorySummary))
+
+	_, err = c.RealtimeAll(ctx, langEN, locUS, "invalid", 0)
+	assert.Equal(t, ErrInvalidCategory, err)
+}
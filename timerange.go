@@ -0,0 +1,210 @@
+package gogtrends
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	timeRangeAll       = "all"
+	timeRangeDateF     = "2006-01-02"
+	timeRangeHourF     = "2006-01-02T15"
+	timeRangeHourlyMax = 7 * 24 * time.Hour
+)
+
+// trendsStart is the first date of Google Trends data.
+var trendsStart = time.Date(2004, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type timeRangeKind int
+
+const (
+	rangeHours timeRangeKind = iota + 1
+	rangeDays
+	rangeMonths
+	rangeYears
+	rangeAll
+	rangeDates
+	rangeDateHours
+)
+
+// relative ranges are rendered as prefix n-unit with allowed n values
+var relativeRanges = map[timeRangeKind]struct {
+	prefix string
+	unit   string
+	allow  []int
+}{
+	rangeHours:  {"now", "H", []int{1, 4}},
+	rangeDays:   {"now", "d", []int{1, 7}},
+	rangeMonths: {"today", "m", []int{1, 3, 12}},
+	rangeYears:  {"today", "y", []int{5}},
+}
+
+// TimeRange is a time period of ComparisonItem in Google Trends format, like "today 12-m" or "2020-01-01 2020-06-01".
+// Use constructors and String to fill ComparisonItem.Time.
+type TimeRange struct {
+	kind     timeRangeKind
+	n        int
+	from, to time.Time
+}
+
+// LastHours is a period of last n hours, Google Trends allows 1 and 4.
+func LastHours(n int) TimeRange {
+	return TimeRange{kind: rangeHours, n: n}
+}
+
+// LastDays is a period of last n days, Google Trends allows 1 and 7.
+func LastDays(n int) TimeRange {
+	return TimeRange{kind: rangeDays, n: n}
+}
+
+// LastMonths is a period of last n months, Google Trends allows 1, 3 and 12.
+func LastMonths(n int) TimeRange {
+	return TimeRange{kind: rangeMonths, n: n}
+}
+
+// LastYears is a period of last n years, Google Trends allows 5.
+func LastYears(n int) TimeRange {
+	return TimeRange{kind: rangeYears, n: n}
+}
+
+// AllTime is a period since 2004.
+func AllTime() TimeRange {
+	return TimeRange{kind: rangeAll}
+}
+
+// Between is a period between dates inclusive, time of dates is ignored.
+func Between(from, to time.Time) TimeRange {
+	return TimeRange{kind: rangeDates, from: truncateDay(from), to: truncateDay(to)}
+}
+
+// BetweenHours is a period between hours in UTC with hourly resolution, Google Trends allows up to 7 days.
+func BetweenHours(from, to time.Time) TimeRange {
+	return TimeRange{kind: rangeDateHours, from: from.UTC().Truncate(time.Hour), to: to.UTC().Truncate(time.Hour)}
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// String renders time range in Google Trends format.
+func (r TimeRange) String() string {
+	switch r.kind {
+	case rangeAll:
+		return timeRangeAll
+	case rangeDates:
+		return r.from.Format(timeRangeDateF) + " " + r.to.Format(timeRangeDateF)
+	case rangeDateHours:
+		return r.from.Format(timeRangeHourF) + " " + r.to.Format(timeRangeHourF)
+	}
+
+	if rel, ok := relativeRanges[r.kind]; ok {
+		return fmt.Sprintf("%s %d-%s", rel.prefix, r.n, rel.unit)
+	}
+
+	return ""
+}
+
+// Validate checks that time range is supported by Google Trends.
+func (r TimeRange) Validate() error {
+	switch r.kind {
+	case rangeAll:
+		return nil
+	case rangeDates, rangeDateHours:
+		// dates are inclusive, so single day is a valid range
+		if r.kind == rangeDates && r.to.Before(r.from) {
+			return errors.Wrapf(ErrInvalidTimeRange, "%s: start is after end", r)
+		}
+
+		if r.kind == rangeDateHours && !r.from.Before(r.to) {
+			return errors.Wrapf(ErrInvalidTimeRange, "%s: start is not before end", r)
+		}
+
+		if r.from.Before(trendsStart) {
+			return errors.Wrapf(ErrInvalidTimeRange, "%s: start is before %s", r, trendsStart.Format(timeRangeDateF))
+		}
+
+		if r.kind == rangeDateHours && r.to.Sub(r.from) > timeRangeHourlyMax {
+			return errors.Wrapf(ErrInvalidTimeRange, "%s: hourly range is longer than 7 days", r)
+		}
+
+		return nil
+	}
+
+	rel, ok := relativeRanges[r.kind]
+	if !ok {
+		return errors.Wrap(ErrInvalidTimeRange, "empty time range")
+	}
+
+	for _, v := range rel.allow {
+		if r.n == v {
+			return nil
+		}
+	}
+
+	return errors.Wrapf(ErrInvalidTimeRange, "%s: allowed values are %v", r, rel.allow)
+}
+
+// ParseTimeRange parses and validates time range in Google Trends format, "+" is accepted instead of space.
+func ParseTimeRange(s string) (TimeRange, error) {
+	str := strings.TrimSpace(strings.ReplaceAll(s, "+", " "))
+	if str == timeRangeAll {
+		return AllTime(), nil
+	}
+
+	parts := strings.Fields(str)
+	if len(parts) != 2 {
+		return TimeRange{}, errors.Wrapf(ErrInvalidTimeRange, "%q", s)
+	}
+
+	r, err := parseTimeRange(parts[0], parts[1])
+	if err != nil {
+		return TimeRange{}, errors.Wrapf(ErrInvalidTimeRange, "%q", s)
+	}
+
+	if err := r.Validate(); err != nil {
+		return TimeRange{}, err
+	}
+
+	return r, nil
+}
+
+func parseTimeRange(first, second string) (TimeRange, error) {
+	// relative range, like "now 7-d"
+	if i := strings.LastIndex(second, "-"); (first == "now" || first == "today") && i > 0 {
+		n, err := strconv.Atoi(second[:i])
+		if err != nil {
+			return TimeRange{}, err
+		}
+
+		for k, v := range relativeRanges {
+			if v.prefix == first && v.unit == second[i+1:] {
+				return TimeRange{kind: k, n: n}, nil
+			}
+		}
+
+		return TimeRange{}, ErrInvalidTimeRange
+	}
+
+	layout := timeRangeDateF
+	kind := rangeDates
+	if strings.Contains(first, "T") {
+		layout = timeRangeHourF
+		kind = rangeDateHours
+	}
+
+	from, err := time.Parse(layout, first)
+	if err != nil {
+		return TimeRange{}, err
+	}
+
+	to, err := time.Parse(layout, second)
+	if err != nil {
+		return TimeRange{}, err
+	}
+
+	return TimeRange{kind: kind, from: from, to: to}, nil
+}
@@ -0,0 +1,74 @@
+package gogtrends
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeRangeString(t *testing.T) {
+	from := time.Date(2020, 1, 1, 15, 30, 0, 0, time.UTC)
+	to := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]TimeRange{
+		"now 1-H":                     LastHours(1),
+		"now 4-H":                     LastHours(4),
+		"now 1-d":                     LastDays(1),
+		"now 7-d":                     LastDays(7),
+		"today 1-m":                   LastMonths(1),
+		"today 3-m":                   LastMonths(3),
+		"today 12-m":                  LastMonths(12),
+		"today 5-y":                   LastYears(5),
+		"all":                         AllTime(),
+		"2020-01-01 2020-06-01":       Between(from, to),
+		"2020-01-01 2020-01-01":       Between(from, from.Add(time.Hour)),
+		"2020-01-01T15 2020-01-03T10": BetweenHours(from, time.Date(2020, 1, 3, 10, 45, 0, 0, time.UTC)),
+	}
+
+	for exp, r := range cases {
+		assert.Equal(t, exp, r.String())
+		assert.NoError(t, r.Validate(), exp)
+
+		parsed, err := ParseTimeRange(exp)
+		assert.NoError(t, err, exp)
+		assert.Equal(t, r, parsed, exp)
+	}
+}
+
+func TestTimeRangeValidate(t *testing.T) {
+	day := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	invalid := []TimeRange{
+		{},
+		LastHours(2),
+		LastDays(30),
+		LastMonths(6),
+		LastYears(1),
+		Between(day, day.AddDate(0, 0, -1)),
+		BetweenHours(day, day),
+		Between(time.Date(2003, 1, 1, 0, 0, 0, 0, time.UTC), day),
+		BetweenHours(day, day.AddDate(0, 0, 8)),
+	}
+
+	for _, v := range invalid {
+		assert.True(t, errors.Is(v.Validate(), ErrInvalidTimeRange), v.String())
+	}
+}
+
+func TestParseTimeRange(t *testing.T) {
+	r, err := ParseTimeRange("today+12-m")
+	assert.NoError(t, err)
+	assert.Equal(t, LastMonths(12), r)
+
+	r, err = ParseTimeRange(" now  7-d ")
+	assert.NoError(t, err)
+	assert.Equal(t, LastDays(7), r)
+
+	for _, v := range []string{"", "today", "now 12-m", "today 2-y", "later 1-d", "now x-d", "2020-01-01", "2020-01-01 2020-13-01",
+		"2020-01-01T10 2020-01-01", "2020-06-01 2020-01-01", "today 12-m extra"} {
+		_, err := ParseTimeRange(v)
+		assert.True(t, errors.Is(err, ErrInvalidTimeRange), v)
+	}
+}
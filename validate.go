@@ -0,0 +1,178 @@
+package gogtrends
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxComparisonItems is a max number of items Google Trends can compare.
+const maxComparisonItems = 5
+
+// FieldError is an invalid field of request.
+type FieldError struct {
+	// Field is a path of field in request, like "comparisonItem[1].geo"
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationError - request is invalid, it lists every offending field.
+// It matches ErrInvalidRequest, so errors.Is(err, ErrInvalidRequest) works.
+type ValidationError []*FieldError
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, v := range e {
+		msgs = append(msgs, v.Error())
+	}
+
+	return errInvalidRequest + ": " + strings.Join(msgs, "; ")
+}
+
+// Unwrap allows to match ValidationError with ErrInvalidRequest.
+func (e ValidationError) Unwrap() error {
+	return ErrInvalidRequest
+}
+
+// Validate checks explore request against Google Trends limits, categories and locations of default client.
+// Categories and locations are requested if they are not cached yet.
+func (r *ExploreRequest) Validate(ctx context.Context) error {
+	return client.ValidateExplore(ctx, r)
+}
+
+// ValidateExplore checks explore request against Google Trends limits and client categories and locations,
+// which are requested if they are not cached yet. Invalid request returns ValidationError.
+func (c *Client) ValidateExplore(ctx context.Context, r *ExploreRequest) error {
+	var cats *ExploreCatTree
+	if r.Category != 0 {
+		tree, err := c.ExploreCategories(ctx)
+		if err != nil {
+			return err
+		}
+		cats = tree
+	}
+
+	var locs *ExploreLocTree
+	for _, v := range r.ComparisonItems {
+		if v != nil && len(v.Geo) > 0 {
+			tree, err := c.ExploreLocations(ctx)
+			if err != nil {
+				return err
+			}
+			locs = tree
+			break
+		}
+	}
+
+	return validateExplore(r, cats, locs)
+}
+
+// validateCached checks request against categories and locations cached in any language without requesting them.
+func (c *Client) validateCached(r *ExploreRequest) error {
+	var cats *ExploreCatTree
+	if tree, ok := c.categories.anyLang(); ok {
+		cats = tree.(*ExploreCatTree)
+	}
+
+	var locs *ExploreLocTree
+	if tree, ok := c.locations.anyLang(); ok {
+		locs = tree.(*ExploreLocTree)
+	}
+
+	return validateExplore(r, cats, locs)
+}
+
+// validateExplore checks request, nil trees skip checks of categories and locations.
+func validateExplore(r *ExploreRequest, cats *ExploreCatTree, locs *ExploreLocTree) error {
+	var errs ValidationError
+	add := func(field, format string, args ...interface{}) {
+		errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch n := len(r.ComparisonItems); {
+	case n == 0:
+		add("comparisonItem", "at least one item is required")
+	case n > maxComparisonItems:
+		add("comparisonItem", "%d items, at most %d can be compared", n, maxComparisonItems)
+	}
+
+	if cats != nil && !cats.contains(r.Category) {
+		add("category", "unknown category %d", r.Category)
+	}
+
+	checked := make([]*ComparisonItem, 0, len(r.ComparisonItems))
+	for i, v := range r.ComparisonItems {
+		field := fmt.Sprintf("comparisonItem[%d]", i)
+		if v == nil {
+			add(field, "item is nil")
+			continue
+		}
+
+		// custom range with start and end time has own format of time
+		if len(v.StartTime) > 0 || len(v.EndTime) > 0 {
+			validateCustomTime(field, v, add)
+		} else if _, err := ParseTimeRange(v.Time); err != nil {
+			add(field+".time", "invalid time range %q", v.Time)
+		}
+
+		if len(v.Geo) > 0 && locs != nil && !locs.contains(v.Geo) {
+			add(field+".geo", "unknown location %q", v.Geo)
+		}
+
+		// different keywords can be compared only in the same location, every pair is checked,
+		// so result doesn't depend on order of items
+		for _, p := range checked {
+			if v.Keyword != p.Keyword && v.Geo != p.Geo {
+				add(field+".geo", "location %q differs from %q of compared keyword %q", v.Geo, p.Geo, p.Keyword)
+				break
+			}
+		}
+		checked = append(checked, v)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+func validateCustomTime(field string, v *ComparisonItem, add func(field, format string, args ...interface{})) {
+	start, err := time.Parse(time.RFC3339Nano, v.StartTime)
+	if err != nil {
+		add(field+".startTime", "invalid time %q", v.StartTime)
+	}
+
+	end, errEnd := time.Parse(time.RFC3339Nano, v.EndTime)
+	if errEnd != nil {
+		add(field+".endTime", "invalid time %q", v.EndTime)
+	}
+
+	if err == nil && errEnd == nil && !start.Before(end) {
+		add(field+".endTime", "end time %q is not after start time %q", v.EndTime, v.StartTime)
+	}
+}
+
+// contains checks if category tree has category with id.
+func (t *ExploreCatTree) contains(id int) bool {
+	if t == nil {
+		return false
+	}
+
+	if t.ID == id {
+		return true
+	}
+
+	for _, v := range t.Children {
+		if v.contains(id) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,122 @@
+package gogtrends
+
+import (
+	"context"
+	"testing"
+
+	"github.com/groovili/gogtrends/gogtrendstest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateExplore(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	valid := []*ExploreRequest{
+		{ComparisonItems: []*ComparisonItem{{Keyword: "Go", Time: "today 12-m"}}},
+		{ComparisonItems: []*ComparisonItem{{Keyword: "Go", Geo: "US-NY", Time: "now+7-d"}}, Category: 31},
+		{ComparisonItems: []*ComparisonItem{{Keyword: "Go", Geo: "US-NY-501", Time: "today 3-m"}}},
+		{ComparisonItems: []*ComparisonItem{{Keyword: "Go", Time: "2020-01-01 2020-01-01"}}},
+		{ComparisonItems: []*ComparisonItem{
+			{Keyword: "Go", Geo: "US", Time: "today 5-y"},
+			{Keyword: "Go", Geo: "GB", Time: "today 5-y"},
+		}},
+		{ComparisonItems: []*ComparisonItem{{
+			Keyword:   "Go",
+			Time:      "2021-09-05T09\\:16\\:00 2021-09-06T09\\:16\\:00",
+			StartTime: "2021-09-05T09:16:00.000Z",
+			EndTime:   "2021-09-06T09:16:00.000Z",
+		}}},
+	}
+
+	for _, v := range valid {
+		assert.NoError(t, c.ValidateExplore(ctx, v))
+	}
+
+	item := &ComparisonItem{Keyword: "Go", Geo: "US", Time: "today 12-m"}
+	err := c.ValidateExplore(ctx, &ExploreRequest{
+		ComparisonItems: []*ComparisonItem{item, item, item, item, item,
+			{Keyword: "Rust", Geo: "GB", Time: "today 12-m"},
+			{Keyword: "Java", Geo: "XX", Time: "today 6-m"},
+		},
+		Category: 1000,
+	})
+	assert.True(t, errors.Is(err, ErrInvalidRequest))
+
+	var verr ValidationError
+	assert.True(t, errors.As(err, &verr))
+
+	fields := make([]string, 0)
+	for _, v := range verr {
+		fields = append(fields, v.Field)
+	}
+	assert.Equal(t, []string{
+		"comparisonItem", "category", "comparisonItem[5].geo", "comparisonItem[6].time",
+		"comparisonItem[6].geo", "comparisonItem[6].geo",
+	}, fields)
+	assert.Contains(t, err.Error(), "comparisonItem[6].geo: unknown location \"XX\"")
+
+	// locations of every pair of different keywords are compared
+	for _, items := range [][]*ComparisonItem{
+		{{Keyword: "Go", Geo: "US"}, {Keyword: "Python", Geo: "US"}, {Keyword: "Go", Geo: "GB"}},
+		{{Keyword: "Go", Geo: "GB"}, {Keyword: "Python", Geo: "GB"}, {Keyword: "Go"}},
+		{{Keyword: "Go"}, {Keyword: "Go", Geo: "GB"}, {Keyword: "Python", Geo: "GB"}},
+	} {
+		for _, v := range items {
+			v.Time = "today 12-m"
+		}
+
+		err = c.ValidateExplore(ctx, &ExploreRequest{ComparisonItems: items})
+		assert.True(t, errors.As(err, &verr))
+		assert.Len(t, verr, 1)
+		assert.Equal(t, "comparisonItem[2].geo", verr[0].Field)
+	}
+
+	// the same keyword in different locations
+	err = c.ValidateExplore(ctx, &ExploreRequest{ComparisonItems: []*ComparisonItem{
+		{Keyword: "Go", Geo: "US", Time: "today 12-m"},
+		{Keyword: "Go", Geo: "GB", Time: "today 12-m"},
+		{Keyword: "Go", Time: "today 12-m"},
+	}})
+	assert.NoError(t, err)
+
+	// metro area is known only with code of its region
+	err = c.ValidateExplore(ctx, &ExploreRequest{ComparisonItems: []*ComparisonItem{{Keyword: "Go", Geo: "501", Time: "today 3-m"}}})
+	assert.True(t, errors.Is(err, ErrInvalidRequest))
+
+	err = c.ValidateExplore(ctx, &ExploreRequest{})
+	assert.Equal(t, "invalid request param: comparisonItem: at least one item is required", err.Error())
+}
+
+func TestExploreValidation(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+	ctx := context.Background()
+
+	// trees are not requested by Explore, limits are checked anyway
+	req := &ExploreRequest{ComparisonItems: []*ComparisonItem{{Keyword: "Go", Geo: "XX", Time: "today 12-m"}}}
+	_, err := c.Explore(ctx, req, langEN)
+	assert.NoError(t, err)
+
+	_, err = c.Explore(ctx, &ExploreRequest{ComparisonItems: []*ComparisonItem{{Keyword: "Go", Time: "yesterday"}}}, langEN)
+	assert.True(t, errors.Is(err, ErrInvalidRequest))
+	assert.Equal(t, 0, srv.Requests(gogtrendstest.PathLocations))
+	assert.Equal(t, 1, srv.Requests(gogtrendstest.PathExplore))
+
+	// cached locations in any language are used
+	_, err = c.ExploreLocationsLang(ctx, "de")
+	assert.NoError(t, err)
+	_, err = c.Explore(ctx, req, langEN)
+	assert.True(t, errors.Is(err, ErrInvalidRequest))
+	assert.Equal(t, 1, srv.Requests(gogtrendstest.PathExplore))
+
+	c = New(WithBaseURL(srv.URL), WithExploreValidation(false))
+	_, err = c.Explore(ctx, &ExploreRequest{ComparisonItems: []*ComparisonItem{{Keyword: "Go", Time: "yesterday"}}}, langEN)
+	assert.NoError(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -19,6 +20,8 @@ const (
 	gSIntOverTime  = "/widgetdata/multiline"
 	gSIntOverReg   = "/widgetdata/comparedgeo"
 	gSAutocomplete = "/autocomplete"
+	gStories       = "/stories"
+	gStorySummary  = "/stories/summary"
 
 	paramHl    = "hl"
 	paramCat   = "cat"
@@ -26,10 +29,33 @@ const (
 	paramReq   = "req"
 	paramTZ    = "tz"
 	paramToken = "token"
+	paramED    = "ed"
+	paramID    = "id"
+
+	// storySummaryBatch is a max number of story ids in one summary request
+	storySummaryBatch = 20
+
+	// dateLayout is a format of dates in daily trends requests and responses
+	dateLayout = "20060102"
 
 	compareDataMode = "PERCENTAGES"
 )
 
+// Endpoints of Google Trends API relative to base url, used to configure client per endpoint.
+const (
+	EndpointDaily              = gDaily
+	EndpointRealtime           = gRealtime
+	EndpointExplore            = gSExplore
+	EndpointCategories         = gSCategories
+	EndpointLocations          = gSGeo
+	EndpointRelated            = gSRelated
+	EndpointInterestOverTime   = gSIntOverTime
+	EndpointInterestByLocation = gSIntOverReg
+	EndpointSearch             = gSAutocomplete
+	EndpointStories            = gStories
+	EndpointStorySummary       = gStorySummary
+)
+
 type WidgetType string
 
 const (
@@ -37,6 +63,7 @@ const (
 	IntOverRegionID     WidgetType = "GEO_MAP"
 	RelatedQueriesID    WidgetType = "RELATED_QUERIES"
 	RelatedTopicsID     WidgetType = "RELATED_TOPICS"
+	NewsArticlesID      WidgetType = "NEWS_ARTICLES"
 )
 
 var (
@@ -65,25 +92,51 @@ type dailyOut struct {
 }
 
 type trendingSearchesDays struct {
-	Searches []*trendingSearchDays `json:"trendingSearchesDays" bson:"trending_search_days"`
+	Searches              []*trendingSearchDays `json:"trendingSearchesDays" bson:"trending_search_days"`
+	EndDateForNextRequest string                `json:"endDateForNextRequest" bson:"end_date_for_next_request"`
+	RSSFeedPageURL        string                `json:"rssFeedPageUrl" bson:"rss_feed_page_url"`
 }
 
 type trendingSearchDays struct {
+	Date          string            `json:"date" bson:"date"`
 	FormattedDate string            `json:"formattedDate" bson:"formatted_date"`
 	Searches      []*TrendingSearch `json:"trendingSearches" bson:"searches"`
 }
 
-// TrendingSearch is a representation trending search in period of 24 hours
-type TrendingSearch struct {
-	Title            *SearchTitle     `json:"title" bson:"title"`
-	FormattedTraffic string           `json:"formattedTraffic" bson:"formatted_traffic"`
-	Image            *SearchImage     `json:"image" bson:"image"`
-	Articles         []*SearchArticle `json:"articles" bson:"articles"`
+// TrendingDay is a list of daily trending searches of specific date.
+type TrendingDay struct {
+	Date          time.Time         `json:"date" bson:"date"`
+	FormattedDate string            `json:"formattedDate" bson:"formatted_date"`
+	Searches      []*TrendingSearch `json:"trendingSearches" bson:"searches"`
 }
 
-// SearchTitle is a user query string for daily trending search
+// DailyPage is a page of daily trends grouped by days with paging details.
+type DailyPage struct {
+	Days []*TrendingDay `json:"days" bson:"days"`
+	// EndDateForNextRequest is a date of the next page of older trends, zero if not provided
+	EndDateForNextRequest time.Time `json:"endDateForNextRequest" bson:"end_date_for_next_request"`
+	RSSFeedPageURL        string    `json:"rssFeedPageUrl" bson:"rss_feed_page_url"`
+}
+
+// TrendingSearch is a representation trending search in period of 24 hours
+type TrendingSearch struct {
+	Title            *SearchTitle `json:"title" bson:"title"`
+	FormattedTraffic string       `json:"formattedTraffic" bson:"formatted_traffic"`
+	// Traffic is a lower bound of searches count parsed from FormattedTraffic, 0 if it can't be parsed
+	Traffic        int64            `json:"traffic" bson:"traffic"`
+	RelatedQueries []*SearchTitle   `json:"relatedQueries" bson:"related_queries"`
+	Image          *SearchImage     `json:"image" bson:"image"`
+	Articles       []*SearchArticle `json:"articles" bson:"articles"`
+	ShareURL       string           `json:"shareUrl" bson:"share_url"`
+	// Date is a day of trend, it is set from day which search belongs to
+	Date          time.Time `json:"date" bson:"date"`
+	FormattedDate string    `json:"formattedDate" bson:"formatted_date"`
+}
+
+// SearchTitle is a user query string for daily trending search with link to explore it
 type SearchTitle struct {
-	Query string `json:"query" bson:"query"`
+	Query       string `json:"query" bson:"query"`
+	ExploreLink string `json:"exploreLink" bson:"explore_link"`
 }
 
 // SearchImage is a picture of trending search
@@ -104,7 +157,8 @@ type SearchArticle struct {
 }
 
 type realtimeOut struct {
-	StorySummaries *storySummary `json:"storySummaries" bson:"story_summaries"`
+	StorySummaries   *storySummary `json:"storySummaries" bson:"story_summaries"`
+	TrendingStoryIDs []string      `json:"trendingStoryIds" bson:"trending_story_ids"`
 }
 
 type storySummary struct {
@@ -113,11 +167,34 @@ type storySummary struct {
 
 // TrendingStory is a representation of realtime trend
 type TrendingStory struct {
-	Title    string             `json:"title" bson:"title"`
-	Image    *SearchImage       `json:"image" bson:"image"`
+	// ID is a story id for StoryDetails
+	ID          string             `json:"id" bson:"id"`
+	Title       string             `json:"title" bson:"title"`
+	EntityNames []string           `json:"entityNames" bson:"entity_names"`
+	Image       *SearchImage       `json:"image" bson:"image"`
+	Articles    []*TrendingArticle `json:"articles" bson:"articles"`
+	ShareURL    string             `json:"shareUrl" bson:"share_url"`
+}
+
+type storyOut struct {
+	Title   string         `json:"title" bson:"title"`
+	Widgets []*storyWidget `json:"widgets" bson:"widgets"`
+}
+
+type storyWidget struct {
+	ExploreWidget
 	Articles []*TrendingArticle `json:"articles" bson:"articles"`
 }
 
+// Story is a realtime story with its own interest over time, all articles and related queries
+type Story struct {
+	ID             string             `json:"id" bson:"id"`
+	Title          string             `json:"title" bson:"title"`
+	Timeline       []*Timeline        `json:"timeline" bson:"timeline"`
+	Articles       []*TrendingArticle `json:"articles" bson:"articles"`
+	RelatedQueries []*RankedKeyword   `json:"relatedQueries" bson:"related_queries"`
+}
+
 // TrendingArticle is an article relative to trending story
 type TrendingArticle struct {
 	Title   string `json:"articleTitle" bson:"title"`
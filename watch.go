@@ -0,0 +1,313 @@
+package gogtrends
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultWatchInterval is used by watchers when interval is not positive.
+	defaultWatchInterval = 5 * time.Minute
+	// maxWatchJitter is the largest jitter fraction, which keeps polling interval positive.
+	maxWatchJitter = 0.99
+)
+
+// WatchEventType is a kind of change between two polls of watcher.
+type WatchEventType string
+
+const (
+	// StoryAppeared - story or daily search is new in the list
+	StoryAppeared WatchEventType = "StoryAppeared"
+	// StoryDropped - story or daily search is not in the list anymore
+	StoryDropped WatchEventType = "StoryDropped"
+	// RankChanged - story or daily search moved in the list
+	RankChanged WatchEventType = "RankChanged"
+	// ArticleAdded - story or daily search has new article
+	ArticleAdded WatchEventType = "ArticleAdded"
+)
+
+// WatchEvent is a change of realtime story or daily search noticed by watcher.
+type WatchEvent struct {
+	Type WatchEventType
+	// Key is a story id or normalized title, which identifies story between polls
+	Key string
+	// Rank is a position in the list starting from 1, 0 for dropped
+	Rank int
+	// PrevRank is a position on previous poll, 0 for appeared
+	PrevRank int
+	// Story is set by Watch, last known story for dropped
+	Story *TrendingStory
+	// Search is set by WatchDaily, last known search for dropped
+	Search *TrendingSearch
+	// ArticleURL is an url of added article for ArticleAdded event
+	ArticleURL string
+	// Time is a time of poll
+	Time time.Time
+}
+
+// WatchOption configures Watch and WatchDaily.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	jitter      float64
+	backoff     RetryPolicy
+	onError     func(error)
+	skipInitial bool
+}
+
+// WithWatchJitter randomizes polling interval by provided fraction, 0.1 is ±10% of interval.
+// Fraction is clamped to [0, 1), so interval stays positive.
+func WithWatchJitter(jitter float64) WatchOption {
+	return func(o *watchOptions) {
+		switch {
+		case jitter < 0:
+			jitter = 0
+		case jitter >= 1:
+			jitter = maxWatchJitter
+		}
+
+		o.jitter = jitter
+	}
+}
+
+// WithWatchBackoff sets exponential delay between polls after errors, interval to 10 intervals by default.
+// Not positive min keeps interval as the first delay, zero max means delay is not limited.
+func WithWatchBackoff(min, max time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		if min > 0 {
+			o.backoff.MinBackoff = min
+		}
+		o.backoff.MaxBackoff = max
+	}
+}
+
+// WithWatchErrorHandler sets function which gets poll errors, errors are skipped by default.
+func WithWatchErrorHandler(fn func(error)) WatchOption {
+	return func(o *watchOptions) {
+		o.onError = fn
+	}
+}
+
+// WithWatchSkipInitial disables StoryAppeared events for stories of the first poll.
+func WithWatchSkipInitial() WatchOption {
+	return func(o *watchOptions) {
+		o.skipInitial = true
+	}
+}
+
+// watchItem is a story or daily search identified by key.
+type watchItem struct {
+	key      string
+	articles []string
+	story    *TrendingStory
+	search   *TrendingSearch
+}
+
+// watchState is a watch item with its rank on previous poll.
+type watchState struct {
+	*watchItem
+	rank       int
+	articleSet map[string]bool
+}
+
+// Watch polls realtime trends with interval and sends changes between polls to returned channel.
+// Stories are keyed by id or normalized title. Channel is closed when ctx is done.
+func (c *Client) Watch(ctx context.Context, hl, loc, cat string, interval time.Duration, opts ...WatchOption) <-chan *WatchEvent {
+	return c.watch(ctx, interval, opts, func(ctx context.Context) ([]*watchItem, error) {
+		stories, err := c.Realtime(ctx, hl, loc, cat)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]*watchItem, 0, len(stories))
+		for _, v := range stories {
+			key := v.ID
+			if len(key) == 0 {
+				key = normalizeTitle(v.Title)
+			}
+
+			urls := make([]string, 0, len(v.Articles))
+			for _, a := range v.Articles {
+				urls = append(urls, a.URL)
+			}
+
+			items = append(items, &watchItem{key: key, articles: urls, story: v})
+		}
+
+		return items, nil
+	})
+}
+
+// WatchDaily polls daily trends with interval and sends changes between polls to returned channel.
+// Searches are keyed by normalized query. Channel is closed when ctx is done.
+func (c *Client) WatchDaily(ctx context.Context, hl, loc string, interval time.Duration, opts ...WatchOption) <-chan *WatchEvent {
+	return c.watch(ctx, interval, opts, func(ctx context.Context) ([]*watchItem, error) {
+		searches, err := c.Daily(ctx, hl, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]*watchItem, 0, len(searches))
+		for _, v := range searches {
+			if v.Title == nil {
+				continue
+			}
+
+			urls := make([]string, 0, len(v.Articles))
+			for _, a := range v.Articles {
+				urls = append(urls, a.URL)
+			}
+
+			items = append(items, &watchItem{key: normalizeTitle(v.Title.Query), articles: urls, search: v})
+		}
+
+		return items, nil
+	})
+}
+
+func (c *Client) watch(ctx context.Context, interval time.Duration, opts []WatchOption,
+	poll func(ctx context.Context) ([]*watchItem, error)) <-chan *WatchEvent {
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	o := &watchOptions{backoff: RetryPolicy{MinBackoff: interval, MaxBackoff: 10 * interval}}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	ch := make(chan *WatchEvent)
+
+	go func() {
+		defer close(ch)
+
+		var prev map[string]*watchState
+		failures := 0
+
+		for {
+			items, err := poll(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+
+			delay := jitter(interval, o.jitter)
+			if err != nil {
+				failures++
+				delay = o.backoff.backoff(failures)
+				c.log().Warn("watch poll failed", "attempt", failures, "delay", delay, "error", err)
+				if o.onError != nil {
+					o.onError(err)
+				}
+			} else {
+				failures = 0
+
+				initial := prev == nil
+				var events []*WatchEvent
+				events, prev = diffWatch(prev, items, time.Now())
+				if initial && o.skipInitial {
+					events = nil
+				}
+
+				for _, e := range events {
+					select {
+					case <-ctx.Done():
+						return
+					case ch <- e:
+					}
+				}
+			}
+
+			if sleep(ctx, delay) != nil {
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// diffWatch compares items with previous state and returns events and new state.
+// Appeared, moved and updated items are in order of list, dropped ones are after them in previous order.
+func diffWatch(prev map[string]*watchState, items []*watchItem, now time.Time) ([]*WatchEvent, map[string]*watchState) {
+	events := make([]*WatchEvent, 0)
+	next := make(map[string]*watchState, len(items))
+
+	for _, v := range items {
+		// daily searches can repeat in different days, the most recent one is kept
+		if _, ok := next[v.key]; ok {
+			continue
+		}
+
+		st := &watchState{watchItem: v, rank: len(next) + 1, articleSet: make(map[string]bool, len(v.articles))}
+		for _, u := range v.articles {
+			st.articleSet[u] = true
+		}
+		next[v.key] = st
+
+		old, ok := prev[v.key]
+		if !ok {
+			events = append(events, newWatchEvent(StoryAppeared, st, 0, now))
+			continue
+		}
+
+		if old.rank != st.rank {
+			events = append(events, newWatchEvent(RankChanged, st, old.rank, now))
+		}
+
+		for _, u := range v.articles {
+			if !old.articleSet[u] {
+				e := newWatchEvent(ArticleAdded, st, old.rank, now)
+				e.ArticleURL = u
+				events = append(events, e)
+			}
+		}
+	}
+
+	dropped := make([]*watchState, 0)
+	for k, v := range prev {
+		if _, ok := next[k]; !ok {
+			dropped = append(dropped, v)
+		}
+	}
+
+	sort.Slice(dropped, func(i, j int) bool {
+		return dropped[i].rank < dropped[j].rank
+	})
+
+	for _, v := range dropped {
+		e := newWatchEvent(StoryDropped, v, v.rank, now)
+		e.Rank = 0
+		events = append(events, e)
+	}
+
+	return events, next
+}
+
+func newWatchEvent(t WatchEventType, st *watchState, prevRank int, now time.Time) *WatchEvent {
+	return &WatchEvent{
+		Type:     t,
+		Key:      st.key,
+		Rank:     st.rank,
+		PrevRank: prevRank,
+		Story:    st.story,
+		Search:   st.search,
+		Time:     now,
+	}
+}
+
+// jitter randomizes d by ±fraction of it.
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+
+	return d + time.Duration((rand.Float64()*2-1)*fraction*float64(d))
+}
+
+// normalizeTitle makes title comparable between polls, case and extra spaces are ignored.
+func normalizeTitle(title string) string {
+	return strings.Join(strings.Fields(strings.ToLower(title)), " ")
+}
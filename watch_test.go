@@ -0,0 +1,176 @@
+package gogtrends
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/groovili/gogtrends/gogtrendstest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffWatch(t *testing.T) {
+	now := time.Now()
+
+	events, state := diffWatch(nil, []*watchItem{
+		{key: "a", articles: []string{"a1"}},
+		{key: "b"},
+		{key: "c"},
+	}, now)
+	assert.Len(t, events, 3)
+	for i, e := range events {
+		assert.Equal(t, StoryAppeared, e.Type)
+		assert.Equal(t, i+1, e.Rank)
+		assert.Equal(t, 0, e.PrevRank)
+	}
+
+	events, _ = diffWatch(state, []*watchItem{
+		{key: "b"},
+		{key: "a", articles: []string{"a1", "a2"}},
+		{key: "d"},
+		{key: "b"},
+	}, now)
+
+	exp := []*WatchEvent{
+		{Type: RankChanged, Key: "b", Rank: 1, PrevRank: 2, Time: now},
+		{Type: RankChanged, Key: "a", Rank: 2, PrevRank: 1, Time: now},
+		{Type: ArticleAdded, Key: "a", Rank: 2, PrevRank: 1, ArticleURL: "a2", Time: now},
+		{Type: StoryAppeared, Key: "d", Rank: 3, Time: now},
+		{Type: StoryDropped, Key: "c", PrevRank: 3, Time: now},
+	}
+	assert.Equal(t, exp, events)
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	assert.Equal(t, "go 1.20 release", normalizeTitle("  Go  1.20\tRelease "))
+}
+
+func TestWatchOptions(t *testing.T) {
+	for in, exp := range map[float64]float64{-1: 0, 0: 0, 0.5: 0.5, 1: maxWatchJitter, 10: maxWatchJitter} {
+		o := new(watchOptions)
+		WithWatchJitter(in)(o)
+		assert.Equal(t, exp, o.jitter, in)
+	}
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, jitter(time.Second, maxWatchJitter) > 0)
+	}
+
+	// zero max doesn't limit backoff
+	o := new(watchOptions)
+	WithWatchBackoff(time.Second, 0)(o)
+	assert.Equal(t, 8*time.Second, o.backoff.backoff(4))
+
+	// zero min keeps polling interval
+	o = &watchOptions{backoff: RetryPolicy{MinBackoff: time.Minute}}
+	WithWatchBackoff(0, time.Hour)(o)
+	assert.Equal(t, time.Minute, o.backoff.backoff(1))
+	assert.Equal(t, time.Hour, o.backoff.MaxBackoff)
+}
+
+func TestWatch(t *testing.T) {
+	payloads := []string{
+		`)]}'{"storySummaries":{"trendingStories":[{"id":"1","title":"One"},{"title":"Two"}]}}`,
+		`)]}'{"storySummaries":{"trendingStories":[{"title":" two "},{"id":"1","title":"One",` +
+			`"articles":[{"url":"https://example.com"}]}]}}`,
+	}
+
+	var polls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(atomic.AddInt32(&polls, 1)) - 1
+		if n >= len(payloads) {
+			n = len(payloads) - 1
+		}
+		_, _ = w.Write([]byte(payloads[n]))
+	}))
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := c.Watch(ctx, langEN, locUS, "all", 10*time.Millisecond, WithWatchJitter(0.5))
+
+	exp := []struct {
+		t    WatchEventType
+		key  string
+		rank int
+	}{
+		{StoryAppeared, "1", 1},
+		{StoryAppeared, "two", 2},
+		{RankChanged, "two", 1},
+		{RankChanged, "1", 2},
+		{ArticleAdded, "1", 2},
+	}
+
+	for _, v := range exp {
+		e := nextEvent(t, events)
+		assert.Equal(t, v.t, e.Type)
+		assert.Equal(t, v.key, e.Key)
+		assert.Equal(t, v.rank, e.Rank)
+		assert.NotNil(t, e.Story)
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+func TestWatchDaily(t *testing.T) {
+	srv := gogtrendstest.NewServer()
+	defer srv.Close()
+
+	c := New(WithBaseURL(srv.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 10)
+	events := c.WatchDaily(ctx, langEN, locUS, 10*time.Millisecond,
+		WithWatchSkipInitial(),
+		WithWatchBackoff(time.Millisecond, 5*time.Millisecond),
+		WithWatchErrorHandler(func(err error) { errs <- err }),
+	)
+
+	// wait for initial poll, then trends of the next day appear
+	for srv.Requests(gogtrendstest.PathDaily) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	srv.Script(gogtrendstest.PathDaily, gogtrendstest.ServerError(http.StatusInternalServerError))
+	srv.SetToday(srv.Today().AddDate(0, 0, 1))
+
+	e := nextEvent(t, events)
+	assert.Equal(t, StoryAppeared, e.Type)
+	assert.Equal(t, 1, e.Rank)
+	assert.Equal(t, normalizeTitle(e.Search.Title.Query), e.Key)
+
+	select {
+	case err := <-errs:
+		assert.True(t, IsServerError(err))
+	case <-time.After(time.Second):
+		t.Fatal("poll error is not handled")
+	}
+
+	cancel()
+	for range events {
+	}
+}
+
+// nextEvent reads event from watcher channel or fails test if it's not sent in time.
+func nextEvent(t *testing.T, events <-chan *WatchEvent) *WatchEvent {
+	t.Helper()
+
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("events channel is closed")
+		}
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("event is not sent")
+	}
+
+	return nil
+}